@@ -44,6 +44,11 @@ output "raw_value" {
   value = data.onefuse_static_property_set.sps01.raw
 }
 
+// All key:value pairs exactly as OneFuse returns them
+output "properties_json" {
+  value = data.onefuse_static_property_set.sps01.properties_json
+}
+
 locals  {
   some_nested_value = jsondecode(data.onefuse_static_property_set.sps01.raw).parent.someNestedKey
 }
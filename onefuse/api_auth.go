@@ -0,0 +1,134 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const AuthMethodBasic = "basic"
+const AuthMethodToken = "token"
+const AuthMethodSession = "session"
+
+// apiAuth holds the credentials used for every request made by a provider instance.
+// It is shared between copies of Config so a session token is only requested once.
+type apiAuth struct {
+	method string
+	token  string
+
+	mu           sync.Mutex
+	sessionToken string
+}
+
+type ApiTokenRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type ApiTokenResponse struct {
+	Token string `json:"token"`
+}
+
+func newAPIAuth(method string, token string) *apiAuth {
+	return &apiAuth{
+		method: method,
+		token:  token,
+	}
+}
+
+func (auth *apiAuth) authorize(config *Config, req *http.Request) error {
+	switch auth.method {
+	case AuthMethodToken:
+		setTokenHeader(req, auth.token)
+	case AuthMethodSession:
+		token, err := auth.session(config)
+		if err != nil {
+			return err
+		}
+		setTokenHeader(req, token)
+	default:
+		req.SetBasicAuth(config.user, config.password)
+	}
+	return nil
+}
+
+// Returns the cached session token, exchanging the configured user and password for one if needed.
+func (auth *apiAuth) session(config *Config) (string, error) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if auth.sessionToken != "" {
+		return auth.sessionToken, nil
+	}
+
+	token, err := requestSessionToken(config)
+	if err != nil {
+		return "", err
+	}
+	auth.sessionToken = token
+
+	return auth.sessionToken, nil
+}
+
+func (auth *apiAuth) invalidate() {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	auth.sessionToken = ""
+}
+
+func requestSessionToken(config *Config) (string, error) {
+	log.Println("onefuse.apiClient: requestSessionToken")
+
+	url := collectionURL(config, ApiTokenResourceType)
+
+	jsonBytes, err := json.Marshal(ApiTokenRequest{Username: config.user, Password: config.password})
+	if err != nil {
+		return "", errors.WithMessage(err, "onefuse.apiClient: Failed to marshal request body to JSON")
+	}
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(jsonBytes)))
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request POST %s", url))
+	}
+
+	setHeaders(req, config)
+
+	client := getHttpClient(config)
+	res, err := client.Do(req)
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request POST %s", url))
+	}
+
+	body, err := readResponse(res)
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to exchange credentials for a session token POST %s", url))
+	}
+	defer res.Body.Close()
+
+	tokenResponse := ApiTokenResponse{}
+	if err = json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", errors.WithMessage(err, "onefuse.apiClient: Failed to unmarshal session token response")
+	}
+
+	if tokenResponse.Token == "" {
+		return "", errors.New("onefuse.apiClient: OneFuse did not return a session token")
+	}
+
+	return tokenResponse.Token, nil
+}
+
+func setTokenHeader(req *http.Request, token string) {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func configForTestServer(t *testing.T, server *httptest.Server) Config {
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Error parsing test server URL '%s': '%s'", server.URL, err)
+	}
+	return NewConfig(serverURL.Scheme, serverURL.Hostname(), serverURL.Port(), "admin", "admin", true)
+}
+
+func TestAuthToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "myPolicy"}`))
+	}))
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	config.auth = newAPIAuth(AuthMethodToken, "my-token")
+
+	policy, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1)
+	if err != nil {
+		t.Errorf("Error getting policy with token auth: '%s'", err)
+		return
+	}
+	if policy.Name != "myPolicy" {
+		t.Errorf("Bad name for policy; expected 'myPolicy' but got '%s'", policy.Name)
+	}
+}
+
+func TestAuthSessionExchangesOnceAndRefreshes(t *testing.T) {
+	var exchanges int32
+	var currentToken atomic.Value
+	currentToken.Store("")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/"+ApiTokenResourceType+"/") {
+			credentials := ApiTokenRequest{}
+			json.NewDecoder(r.Body).Decode(&credentials)
+			if credentials.Username != "admin" || credentials.Password != "admin" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			n := atomic.AddInt32(&exchanges, 1)
+			token := strings.Repeat("t", int(n))
+			currentToken.Store(token)
+			json.NewEncoder(w).Encode(ApiTokenResponse{Token: token})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+currentToken.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	config.auth = newAPIAuth(AuthMethodSession, "")
+	apiClient := config.NewOneFuseApiClient()
+
+	for i := 0; i < 3; i++ {
+		if _, err := apiClient.GetMicrosoftADPolicy(1); err != nil {
+			t.Errorf("Error getting policy with session auth: '%s'", err)
+			return
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("Expected credentials to be exchanged once but got %d", exchanges)
+	}
+
+	// Expire the session on the server side
+	currentToken.Store("expired")
+
+	if _, err := apiClient.GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy after session expiry: '%s'", err)
+		return
+	}
+	if exchanges != 2 {
+		t.Errorf("Expected credentials to be exchanged again after a 401 but got %d exchanges", exchanges)
+	}
+}
+
+func TestAuthBasicWithoutAuthSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy with basic auth: '%s'", err)
+	}
+}
+
+func TestProviderAuthMethodDefault(t *testing.T) {
+	token, tokenSet := os.LookupEnv("ONEFUSE_TOKEN")
+	os.Unsetenv("ONEFUSE_TOKEN")
+	if tokenSet {
+		defer os.Setenv("ONEFUSE_TOKEN", token)
+	}
+
+	for _, table := range []struct {
+		raw    map[string]interface{}
+		method string
+	}{
+		{map[string]interface{}{"user": "admin", "password": "admin"}, AuthMethodBasic},
+		{map[string]interface{}{"auth": []interface{}{map[string]interface{}{"token": "my-token"}}}, AuthMethodToken},
+		{map[string]interface{}{"user": "admin", "password": "admin", "auth": []interface{}{map[string]interface{}{"method": AuthMethodSession}}}, AuthMethodSession},
+	} {
+		auth, err := configureAuth(schema.TestResourceDataRaw(t, Provider().Schema, table.raw))
+		if err != nil {
+			t.Errorf("Error configuring auth for %v: '%s'", table.raw, err)
+			continue
+		}
+		if auth.method != table.method {
+			t.Errorf("Bad auth method for %v; expected '%s' but got '%s'", table.raw, table.method, auth.method)
+		}
+	}
+}
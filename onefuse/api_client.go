@@ -7,12 +7,14 @@
 package onefuse
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"path"
 	"reflect"
 	"strconv"
@@ -39,9 +41,11 @@ const StaticPropertySetResourceType = "propertySets"
 const RenderTemplateType = "templateTester"
 const IPAMPolicyResourceType = "ipamPolicies"
 const NamingPolicyResourceType = "namingPolicies"
+const NamingSequenceResourceType = "namingSequences"
 const ADPolicyResourceType = "microsoftADPolicies"
 const DNSPolicyResourceType = "dnsPolicies"
 const ScriptingPolicyResourceType = "scriptingPolicies"
+const CredentialResourceType = "moduleCredentials"
 const JobStatusResourceType = "jobStatus"
 const ScriptingDepoloymentResourceType = "scriptingDeployments"
 const VraDeploymentResourceType = "vraDeployments"
@@ -49,6 +53,7 @@ const ServicenowCMDBPolicyResourceType = "servicenowCMDBPolicies"
 const ServicenowCMDBDepoloymentResourceType = "servicenowCMDBDeployments"
 const VraPolicyResourceType = "vraPolicies"
 const JobMetaDataResourceType = "jobMetadata"
+const ApiTokenResourceType = "apiToken"
 
 const JobSuccess = "Successful"
 const JobFailed = "Failed"
@@ -61,7 +66,7 @@ type CustomName struct {
 	Id        int
 	Name      string
 	DnsSuffix string
-	Links *struct {
+	Links     *struct {
 		Self        LinkRef `json:"self,omitempty"`
 		Workspace   LinkRef `json:"workspace,omitempty"`
 		Policy      LinkRef `json:"policy,omitempty"`
@@ -70,8 +75,8 @@ type CustomName struct {
 }
 
 type JobMetaData struct {
-    ID               int                    `json:"id"`
-    ResolvedProperties map[string]interface{} `json:"resolvedProperties"`
+	ID                 int                    `json:"id"`
+	ResolvedProperties map[string]interface{} `json:"resolvedProperties"`
 }
 
 type LinkRef struct {
@@ -79,12 +84,27 @@ type LinkRef struct {
 	Title string `json:"title,omitempty"`
 }
 
+// Returns the ID at the end of the link's href, or 0 when there is no link.
+func (link LinkRef) ID() (int, error) {
+	if link.Href == "" {
+		return 0, nil
+	}
+
+	hrefSplit := strings.Split(strings.TrimSuffix(link.Href, "/"), "/")
+	id, err := strconv.Atoi(hrefSplit[len(hrefSplit)-1])
+	if err != nil {
+		return 0, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Expected an ID at the end of '%s'", link.Href))
+	}
+	return id, nil
+}
+
 type Workspace struct {
 	Links *struct {
 		Self LinkRef `json:"self,omitempty"`
 	} `json:"_links,omitempty"`
-	ID   int    `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description"`
 }
 
 type WorkspacesListResponse struct {
@@ -95,24 +115,84 @@ type WorkspacesListResponse struct {
 
 type EndpointsListResponse struct {
 	Embedded struct {
-		Endpoints []MicrosoftEndpoint `json:"endpoints"` // TODO: Generalize to Endpoints
+		Endpoints []Endpoint `json:"endpoints"`
+	} `json:"_embedded"`
+}
+
+type CredentialsListResponse struct {
+	Embedded struct {
+		Credentials []Credential `json:"moduleCredentials"`
+	} `json:"_embedded"`
+}
+
+// A username and password endpoints connect with. OneFuse never returns the password.
+type Credential struct {
+	Links *struct {
+		Self      LinkRef `json:"self,omitempty"`
+		Workspace LinkRef `json:"workspace,omitempty"`
+	} `json:"_links,omitempty"`
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	WorkspaceURL string `json:"workspace,omitempty"`
+}
+
+type MicrosoftEndpointsListResponse struct {
+	Embedded struct {
+		Endpoints []MicrosoftEndpoint `json:"endpoints"`
 	} `json:"_embedded"`
 }
 
+// The types of endpoint OneFuse supports, which all share the "endpoints" collection.
+const (
+	MicrosoftEndpointType    = "microsoft"
+	InfobloxEndpointType     = "infoblox"
+	BluecatEndpointType      = "bluecat"
+	MenAndMiceEndpointType   = "menandmice"
+	AnsibleTowerEndpointType = "ansible_tower"
+	VraEndpointType          = "vra"
+	ServicenowEndpointType   = "servicenow"
+	ModuleEndpointType       = "module"
+)
+
+// An endpoint of any type. Microsoft Endpoints have settings of their own, see MicrosoftEndpoint.
+type Endpoint struct {
+	Links *struct {
+		Self       LinkRef `json:"self,omitempty"`
+		Workspace  LinkRef `json:"workspace,omitempty"`
+		Credential LinkRef `json:"credential,omitempty"`
+	} `json:"_links,omitempty"`
+	ID           int     `json:"id,omitempty"`
+	Type         string  `json:"type,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Description  string  `json:"description"`
+	Host         string  `json:"host,omitempty"`
+	Port         int     `json:"port,omitempty"`
+	SSL          bool    `json:"ssl"`
+	CredentialID int     `json:"-"`
+	Credential   *string `json:"credential"`
+	WorkspaceURL string  `json:"workspace,omitempty"`
+}
+
 type MicrosoftEndpoint struct {
 	Links *struct {
 		Self       LinkRef `json:"self,omitempty"`
 		Workspace  LinkRef `json:"workspace,omitempty"`
 		Credential LinkRef `json:"credential,omitempty"`
 	} `json:"_links,omitempty"`
-	ID               int    `json:"id,omitempty"`
-	Type             string `json:"type,omitempty"`
-	Name             string `json:"name,omitempty"`
-	Description      string `json:"description,omitempty"`
-	Host             string `json:"host,omitempty"`
-	Port             int    `json:"port,omitempty"`
-	SSL              bool   `json:"ssl,omitempty"`
-	MicrosoftVersion string `json:"microsoftVersion,omitempty"`
+	ID               int     `json:"id,omitempty"`
+	Type             string  `json:"type,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	Description      string  `json:"description"`
+	Host             string  `json:"host,omitempty"`
+	Port             int     `json:"port,omitempty"`
+	SSL              bool    `json:"ssl"`
+	MicrosoftVersion string  `json:"microsoftVersion,omitempty"`
+	CredentialID     int     `json:"-"`
+	Credential       *string `json:"credential"`
+	WorkspaceURL     string  `json:"workspace,omitempty"`
 }
 
 type MicrosoftADPolicy struct {
@@ -123,15 +203,15 @@ type MicrosoftADPolicy struct {
 	} `json:"_links,omitempty"`
 	Name                   string   `json:"name,omitempty"`
 	ID                     int      `json:"id,omitempty"`
-	Description            string   `json:"description,omitempty"`
+	Description            string   `json:"description"`
 	MicrosoftEndpointID    int      `json:"microsoftEndpointId,omitempty"`
 	MicrosoftEndpoint      string   `json:"microsoftEndpoint,omitempty"`
 	ComputerNameLetterCase string   `json:"computerNameLetterCase,omitempty"`
 	WorkspaceURL           string   `json:"workspace,omitempty"`
-	OU                     string   `json:"ou,omitempty"`
-	CreateOU               bool     `json:"createOrganizationalUnit,omitempty"`
-	RemoveOU               bool     `json:"removeOrganizationalUnit,omitempty"`
-	SecurityGroups         []string `json:"securityGroups,omitempty"`
+	OU                     string   `json:"ou"`
+	CreateOU               bool     `json:"createOrganizationalUnit"`
+	RemoveOU               bool     `json:"removeOrganizationalUnit"`
+	SecurityGroups         []string `json:"securityGroups"`
 }
 
 type MicrosoftADComputerAccount struct {
@@ -187,7 +267,7 @@ type IPAMReservation struct {
 	Network            string                 `json:"network,omitempty"`
 	Subnet             string                 `json:"subnet,omitempty"`
 	DNSSuffix          string                 `json:"dnsSuffix,omitempty"`
-    DNSSearchSuffixes  string                 `json:"dnsSearchSuffixes,omitempty"`
+	DNSSearchSuffixes  string                 `json:"dnsSearchSuffixes,omitempty"`
 	Netmask            string                 `json:"netmask,omitempty"`
 	NicLabel           string                 `json:"nicLabel,omitempty"`
 	TemplateProperties map[string]interface{} `json:"template_properties,omitempty"`
@@ -204,11 +284,13 @@ type StaticPropertySet struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int                    `json:"id,omitempty"`
-	Name        string                 `json:"name,omitempty"`
-	Description string                 `json:"description,omitempty"`
-	Properties  map[string]interface{} `json:"properties,omitempty"`
-	Raw         string
+	ID             int                    `json:"id,omitempty"`
+	Name           string                 `json:"name,omitempty"`
+	Description    string                 `json:"description"`
+	PropertiesJSON json.RawMessage        `json:"properties,omitempty"`
+	Properties     map[string]interface{} `json:"-"`
+	Raw            string                 `json:"-"`
+	WorkspaceURL   string                 `json:"workspace,omitempty"`
 }
 
 type RenderTemplateResponse struct {
@@ -230,10 +312,23 @@ type IPAMPolicy struct {
 	Links *struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
+		Endpoint  LinkRef `json:"endpoint,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                        int      `json:"id,omitempty"`
+	Name                      string   `json:"name,omitempty"`
+	Description               string   `json:"description"`
+	EndpointID                int      `json:"-"`
+	Endpoint                  string   `json:"endpoint,omitempty"`
+	Networks                  []string `json:"networks,omitempty"`
+	HostnameOverride          string   `json:"hostnameOverride"`
+	DnsSuffix                 string   `json:"dnsSuffix"`
+	DnsSearchSuffixes         string   `json:"dnsSearchSuffixes"`
+	Gateway                   string   `json:"gateway"`
+	PrimaryDns                string   `json:"primaryDns"`
+	SecondaryDns              string   `json:"secondaryDns"`
+	NicLabel                  string   `json:"nicLabel"`
+	UpdateConflictNameWithDns string   `json:"updateConflictNameWithDns,omitempty"`
+	WorkspaceURL              string   `json:"workspace,omitempty"`
 }
 
 type NamingPolicyResponse struct {
@@ -244,12 +339,18 @@ type NamingPolicyResponse struct {
 
 type NamingPolicy struct {
 	Links *struct {
-		Self      LinkRef `json:"self,omitempty"`
-		Workspace LinkRef `json:"workspace,omitempty"`
+		Self           LinkRef `json:"self,omitempty"`
+		Workspace      LinkRef `json:"workspace,omitempty"`
+		NamingSequence LinkRef `json:"namingSequence,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                int     `json:"id,omitempty"`
+	Name              string  `json:"name,omitempty"`
+	Description       string  `json:"description"`
+	NameTemplate      string  `json:"nameTemplate,omitempty"`
+	DnsSuffixTemplate string  `json:"dnsSuffix"`
+	NamingSequenceID  int     `json:"-"`
+	NamingSequence    *string `json:"namingSequence"`
+	WorkspaceURL      string  `json:"workspace,omitempty"`
 }
 
 type ADPolicyResponse struct {
@@ -278,10 +379,24 @@ type DNSPolicy struct {
 	Links *struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
+		Endpoint  LinkRef `json:"endpoint,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID           int               `json:"id,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Description  string            `json:"description"`
+	EndpointID   int               `json:"-"`
+	Endpoint     string            `json:"endpoint,omitempty"`
+	Zones        []string          `json:"zones,omitempty"`
+	Records      []DNSPolicyRecord `json:"records,omitempty"`
+	TTL          int               `json:"ttl,omitempty"`
+	WorkspaceURL string            `json:"workspace,omitempty"`
+}
+
+// A record OneFuse creates for each DNS Reservation, with templated name and value.
+type DNSPolicyRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type ServicenowCMDBPolicyResponse struct {
@@ -294,10 +409,32 @@ type ServicenowCMDBPolicy struct {
 	Links *struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
+		Endpoint  LinkRef `json:"endpoint,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                     int                               `json:"id,omitempty"`
+	Name                   string                            `json:"name,omitempty"`
+	Description            string                            `json:"description"`
+	EndpointID             int                               `json:"-"`
+	Endpoint               string                            `json:"endpoint,omitempty"`
+	ConfigurationItems     []ServicenowCMDBConfigurationItem `json:"configurationItemsInfo,omitempty"`
+	Relationships          []ServicenowCMDBRelationship      `json:"relationships"`
+	ExecutionStrategy      string                            `json:"executionStrategy,omitempty"`
+	UpdateConflictStrategy string                            `json:"updateConflictStrategy,omitempty"`
+	WorkspaceURL           string                            `json:"workspace,omitempty"`
+}
+
+// A configuration item a ServiceNow CMDB Policy creates, with templates for its attributes.
+type ServicenowCMDBConfigurationItem struct {
+	ClassName  string            `json:"ciClassName"`
+	Name       string            `json:"ciName"`
+	Attributes map[string]string `json:"ciAttributes,omitempty"`
+}
+
+// A relationship between two configuration items, referenced by their names.
+type ServicenowCMDBRelationship struct {
+	Parent string `json:"parentCiName"`
+	Child  string `json:"childCiName"`
+	Type   string `json:"relationshipType"`
 }
 
 type ServicenowCMDBDeployment struct {
@@ -396,14 +533,24 @@ type ScriptingPolicyResponse struct {
 	} `json:"_embedded"`
 }
 
+// Without an endpoint or credential, null is sent to unset it.
 type ScriptingPolicy struct {
 	Links *struct {
-		Self      LinkRef `json:"self,omitempty"`
-		Workspace LinkRef `json:"workspace,omitempty"`
+		Self       LinkRef `json:"self,omitempty"`
+		Workspace  LinkRef `json:"workspace,omitempty"`
+		Endpoint   LinkRef `json:"endpoint,omitempty"`
+		Credential LinkRef `json:"credential,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                   int     `json:"id,omitempty"`
+	Name                 string  `json:"name,omitempty"`
+	Description          string  `json:"description"`
+	EndpointID           int     `json:"-"`
+	Endpoint             *string `json:"endpoint"`
+	CredentialID         int     `json:"-"`
+	Credential           *string `json:"credential"`
+	ProvisioningScript   string  `json:"provisioningScript,omitempty"`
+	DeprovisioningScript string  `json:"deprovisioningScript"`
+	WorkspaceURL         string  `json:"workspace,omitempty"`
 }
 
 type AnsibleTowerPolicyResponse struct {
@@ -416,10 +563,25 @@ type AnsibleTowerPolicy struct {
 	Links *struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
+		Endpoint  LinkRef `json:"endpoint,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                         int                       `json:"id,omitempty"`
+	Name                       string                    `json:"name,omitempty"`
+	Description                string                    `json:"description"`
+	EndpointID                 int                       `json:"-"`
+	Endpoint                   string                    `json:"endpoint,omitempty"`
+	InventoryName              string                    `json:"inventoryName"`
+	Hosts                      []string                  `json:"hosts"`
+	Limit                      string                    `json:"limit"`
+	ProvisioningJobTemplates   []AnsibleTowerJobTemplate `json:"provisioningJobTemplates"`
+	DeprovisioningJobTemplates []AnsibleTowerJobTemplate `json:"deprovisioningJobTemplates"`
+	WorkspaceURL               string                    `json:"workspace,omitempty"`
+}
+
+// A job template an Ansible Tower Policy launches, with the extra vars passed to it.
+type AnsibleTowerJobTemplate struct {
+	Name      string                 `json:"name"`
+	ExtraVars map[string]interface{} `json:"extraVars,omitempty"`
 }
 
 // add outputs to this struct once deploy is done
@@ -454,10 +616,18 @@ type VraPolicy struct {
 	Links *struct {
 		Self      LinkRef `json:"self,omitempty"`
 		Workspace LinkRef `json:"workspace,omitempty"`
+		Endpoint  LinkRef `json:"endpoint,omitempty"`
 	} `json:"_links,omitempty"`
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                     int               `json:"id,omitempty"`
+	Name                   string            `json:"name,omitempty"`
+	Description            string            `json:"description"`
+	EndpointID             int               `json:"-"`
+	Endpoint               string            `json:"endpoint,omitempty"`
+	BlueprintName          string            `json:"blueprintName,omitempty"`
+	ProjectName            string            `json:"projectName,omitempty"`
+	DeploymentNameTemplate string            `json:"deploymentNameTemplate"`
+	InputTemplates         map[string]string `json:"inputTemplates"`
+	WorkspaceURL           string            `json:"workspace,omitempty"`
 }
 type ModuleDeployment struct {
 	Links *struct {
@@ -466,13 +636,13 @@ type ModuleDeployment struct {
 		Policy      LinkRef `json:"policy,omitempty"`
 		JobMetadata LinkRef `json:"jobMetadata,omitempty"`
 	} `json:"_links,omitempty"`
-	ID                       int                    `json:"id,omitempty"`
-	PolicyID                 int                    `json:"policyId,omitempty"`
-	Policy                   string                 `json:"policy,omitempty"`
-	WorkspaceURL             string                 `json:"workspace,omitempty"`
-	Name                     string                 `json:"name,omitempty"`
-	Archived                 bool                   `json:"archived,omitempty"`
-	TemplateProperties       map[string]interface{} `json:"templateProperties"`
+	ID                     int                    `json:"id,omitempty"`
+	PolicyID               int                    `json:"policyId,omitempty"`
+	Policy                 string                 `json:"policy,omitempty"`
+	WorkspaceURL           string                 `json:"workspace,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	Archived               bool                   `json:"archived,omitempty"`
+	TemplateProperties     map[string]interface{} `json:"templateProperties"`
 	ProvisioningJobResults []struct {
 		Output          string `json:"output"`
 		Status          string `json:"status"`
@@ -493,13 +663,21 @@ type ModulePolicyResponse struct {
 
 type ModulePolicy struct {
 	Links *struct {
-		Self      LinkRef `json:"self,omitempty"`
-		Workspace LinkRef `json:"workspace,omitempty"`
+		Self        LinkRef   `json:"self,omitempty"`
+		Workspace   LinkRef   `json:"workspace,omitempty"`
+		Endpoint    LinkRef   `json:"endpoint,omitempty"`
+		Credentials []LinkRef `json:"credentials,omitempty"`
 	} `json:"_links,omitempty"`
-	ID             int    `json:"id,omitempty"`
-	Name           string `json:"name,omitempty"`
-	Description    string `json:"description,omitempty"`
-	PolicyTemplate string `json:"policyTemplate,omitempty"`
+	ID             int      `json:"id,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description"`
+	ModuleName     string   `json:"moduleName,omitempty"`
+	EndpointID     int      `json:"-"`
+	Endpoint       string   `json:"endpoint,omitempty"`
+	CredentialIDs  []int    `json:"-"`
+	Credentials    []string `json:"credentials"`
+	PolicyTemplate string   `json:"policyTemplate,omitempty"`
+	WorkspaceURL   string   `json:"workspace,omitempty"`
 }
 
 func (c *Config) NewOneFuseApiClient() *OneFuseAPIClient {
@@ -508,6 +686,16 @@ func (c *Config) NewOneFuseApiClient() *OneFuseAPIClient {
 	}
 }
 
+// Returns a client that stops waiting for OneFuse jobs after timeout.
+// Resources pass the matching d.Timeout(schema.TimeoutCreate), TimeoutUpdate or TimeoutDelete.
+func (apiClient *OneFuseAPIClient) WithTimeout(timeout time.Duration) *OneFuseAPIClient {
+	config := *apiClient.config
+	config.jobTimeout = timeout
+	return &OneFuseAPIClient{
+		config: &config,
+	}
+}
+
 func (apiClient *OneFuseAPIClient) GenerateCustomName(namingPolicyID string, workspaceID string, templateProperties map[string]interface{}) (*CustomName, error) {
 	log.Println("onefuse.apiClient: GenerateCustomName")
 
@@ -517,22 +705,18 @@ func (apiClient *OneFuseAPIClient) GenerateCustomName(namingPolicyID string, wor
 		templateProperties = make(map[string]interface{})
 	}
 
-	if workspaceID == "" {
-		defaultWorkspaceID, err := findDefaultWorkspaceID(config)
-		if err != nil {
-			return nil, err
-		}
-		workspaceID = defaultWorkspaceID
+	workspaceURL, err := findWorkspaceURLOrDefault(config, workspaceID)
+	if err != nil {
+		return nil, err
 	}
 
 	postBody := map[string]interface{}{
 		"policy":             fmt.Sprintf("/%s/%s/namingPolicies/%s/", ApiVersion, ApiNamespace, namingPolicyID),
 		"templateProperties": templateProperties,
-		"workspace":          fmt.Sprintf("/%s/%s/workspaces/%s/", ApiVersion, ApiNamespace, workspaceID),
+		"workspace":          workspaceURL,
 	}
 
 	var req *http.Request
-	var err error
 	if req, err = buildPostRequest(config, NamingResourceType, postBody); err != nil {
 		return nil, err
 	}
@@ -590,12 +774,39 @@ func (apiClient *OneFuseAPIClient) DeleteCustomName(id int) error {
 
 func (apiClient *OneFuseAPIClient) CreateMicrosoftEndpoint(newEndpoint MicrosoftEndpoint) (*MicrosoftEndpoint, error) {
 	log.Println("onefuse.apiClient: CreateMicrosoftEndpoint")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+
+	config := apiClient.config
+
+	if err := prepareMicrosoftEndpoint(config, &newEndpoint); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, ModuleEndpointResourceType, newEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := MicrosoftEndpoint{}
+	if err = doRequestAndUnmarshal(config, req, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
 }
 
 func (apiClient *OneFuseAPIClient) GetMicrosoftEndpoint(id int) (*MicrosoftEndpoint, error) {
 	log.Println("onefuse.apiClient: GetMicrosoftEndpoint")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+
+	config := apiClient.config
+
+	url := itemURL(config, ModuleEndpointResourceType, id)
+
+	endpoint := MicrosoftEndpoint{}
+	if err := doGet(config, url, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
 }
 
 func (apiClient *OneFuseAPIClient) GetMicrosoftEndpointByName(name string) (*MicrosoftEndpoint, error) {
@@ -603,8 +814,8 @@ func (apiClient *OneFuseAPIClient) GetMicrosoftEndpointByName(name string) (*Mic
 
 	config := apiClient.config
 
-	endpoints := EndpointsListResponse{}
-	entity, err := findEntityByName(config, name, ModuleEndpointResourceType, &endpoints, "Endpoints", ";type:microsoft")
+	endpoints := MicrosoftEndpointsListResponse{}
+	entity, err := findEntityByName(config, name, ModuleEndpointResourceType, &endpoints, "Endpoints", ";type.exact:"+MicrosoftEndpointType)
 	if err != nil {
 		return nil, err
 	}
@@ -615,13 +826,51 @@ func (apiClient *OneFuseAPIClient) GetMicrosoftEndpointByName(name string) (*Mic
 func (apiClient *OneFuseAPIClient) UpdateMicrosoftEndpoint(id int, updatedEndpoint MicrosoftEndpoint) (*MicrosoftEndpoint, error) {
 	log.Println("onefuse.apiClient: UpdateMicrosoftEndpoint")
 
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+	config := apiClient.config
+
+	if updatedEndpoint.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Microsoft Endpoint Updates Require a Name")
+	}
+
+	if err := prepareMicrosoftEndpoint(config, &updatedEndpoint); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, ModuleEndpointResourceType, updatedEndpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := MicrosoftEndpoint{}
+	if err = doRequestAndUnmarshal(config, req, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
 }
 
 func (apiClient *OneFuseAPIClient) DeleteMicrosoftEndpoint(id int) error {
 	log.Println("onefuse.apiClient: DeleteMicrosoftEndpoint")
 
-	return errors.New("onefuse.apiClient: Not implemented yet")
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, ModuleEndpointResourceType, id))
+}
+
+func prepareMicrosoftEndpoint(config *Config, endpoint *MicrosoftEndpoint) error {
+	var err error
+	if endpoint.WorkspaceURL, err = findWorkspaceURLOrDefault(config, endpoint.WorkspaceURL); err != nil {
+		return err
+	}
+
+	endpoint.Type = MicrosoftEndpointType
+
+	if endpoint.Credential == nil && endpoint.CredentialID != 0 {
+		credential := itemURL(config, CredentialResourceType, endpoint.CredentialID)
+		endpoint.Credential = &credential
+	}
+
+	return nil
 }
 
 func (apiClient *OneFuseAPIClient) CreateMicrosoftADPolicy(newPolicy *MicrosoftADPolicy) (*MicrosoftADPolicy, error) {
@@ -634,14 +883,16 @@ func (apiClient *OneFuseAPIClient) CreateMicrosoftADPolicy(newPolicy *MicrosoftA
 		return nil, err
 	}
 
+	if newPolicy.SecurityGroups == nil {
+		newPolicy.SecurityGroups = []string{}
+	}
+
 	var req *http.Request
 	if req, err = buildPostRequest(config, MicrosoftADPolicyResourceType, newPolicy); err != nil {
 		return nil, err
 	}
 
-	client := getHttpClient(config)
-
-	res, err := client.Do(req)
+	res, err := doRequest(config, req)
 	if err != nil {
 		body, _ := ioutil.ReadAll(req.Body)
 		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request POST %s %s", req.URL, body))
@@ -699,6 +950,10 @@ func (apiClient *OneFuseAPIClient) UpdateMicrosoftADPolicy(id int, updatedPolicy
 		return nil, err
 	}
 
+	if updatedPolicy.SecurityGroups == nil {
+		updatedPolicy.SecurityGroups = []string{}
+	}
+
 	jsonBytes, err := json.Marshal(updatedPolicy)
 	if err != nil {
 		return nil, errors.WithMessage(err, "onefuse.apiClient: Failed to marshal request body to JSON")
@@ -714,16 +969,14 @@ func (apiClient *OneFuseAPIClient) UpdateMicrosoftADPolicy(id int, updatedPolicy
 
 	setHeaders(req, config)
 
-	client := getHttpClient(config)
-
-	res, err := client.Do(req)
+	res, err := doRequest(config, req)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request PUT %s %s", url, requestBody))
 	}
 
 	err = checkForErrors(res)
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Error from request PUT %s %s", url, err))
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Request failed PUT %s", requestBody))
 	}
 
 	body, err := ioutil.ReadAll(res.Body)
@@ -754,12 +1007,11 @@ func (apiClient *OneFuseAPIClient) DeleteMicrosoftADPolicy(id int) error {
 
 	setHeaders(req, config)
 
-	client := getHttpClient(config)
-
-	res, err := client.Do(req)
+	res, err := doRequest(config, req)
 	if err != nil {
 		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request DELETE %s", url))
 	}
+	defer res.Body.Close()
 
 	return checkForErrors(res)
 }
@@ -898,6 +1150,9 @@ func buildPostRequest(config *Config, resourceType string, requestEntity interfa
 	return req, nil
 }
 
+// OneFuse keeps the value of any field left out of a PUT, so the structs sent here send optional
+// fields that can be cleared even when they are empty: without omitempty, as [] for lists and as
+// null for links.
 func buildPutRequest(config *Config, resourceType string, requestEntity interface{}, id int) (*http.Request, error) {
 	url := itemURL(config, resourceType, id)
 
@@ -1059,7 +1314,7 @@ func (apiClient *OneFuseAPIClient) CreateAnsibleTowerDeployment(newAnsibleTowerD
 
 	if newAnsibleTowerDeployment.Policy == "" {
 		if newAnsibleTowerDeployment.PolicyID != 0 {
-			newAnsibleTowerDeployment.Policy = itemURL(config, WorkspaceResourceType, newAnsibleTowerDeployment.PolicyID)
+			newAnsibleTowerDeployment.Policy = itemURL(config, AnsibleTowerPolicyResourceType, newAnsibleTowerDeployment.PolicyID)
 		} else {
 			return nil, errors.New("onefuse.apiClient: Ansible Tower Deployment Create requires a PolicyID or Policy URL")
 		}
@@ -1207,18 +1462,106 @@ func (apiClient *OneFuseAPIClient) DeleteVraDeployment(id int) error {
 
 // Start IPAM Policies
 
+func (apiClient *OneFuseAPIClient) CreateIPAMPolicy(newPolicy *IPAMPolicy) (*IPAMPolicy, error) {
+	log.Println("onefuse.apiClient: CreateIPAMPolicy")
+
+	config := apiClient.config
+
+	if err := prepareIPAMPolicy(config, newPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, IPAMPolicyResourceType, newPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := IPAMPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
 func (apiClient *OneFuseAPIClient) GetIPAMPolicy(id int) (*IPAMPolicy, error) {
 	log.Println("onefuse.apiClient: GetIPAMPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+
+	config := apiClient.config
+
+	url := itemURL(config, IPAMPolicyResourceType, id)
+
+	policy := IPAMPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateIPAMPolicy(id int, updatedPolicy *IPAMPolicy) (*IPAMPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateIPAMPolicy")
+
+	config := apiClient.config
+
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: IPAM Policy Updates Require a Name")
+	}
+
+	if err := prepareIPAMPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, IPAMPolicyResourceType, updatedPolicy, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := IPAMPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteIPAMPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteIPAMPolicy")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, IPAMPolicyResourceType, id))
+}
+
+// Fills in the workspace and IPAM Endpoint URLs OneFuse expects on an IPAM Policy.
+func prepareIPAMPolicy(config *Config, policy *IPAMPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: IPAM Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
+
+	return nil
 }
 
-func (apiClient *OneFuseAPIClient) GetIPAMPolicyByName(name string) (*IPAMPolicy, error) {
+func (apiClient *OneFuseAPIClient) GetIPAMPolicyByName(name string, workspace string) (*IPAMPolicy, error) {
 	log.Println("onefuse.apiClient: GetIPAMPolicyByName")
 
 	config := apiClient.config
 
 	ipamPolicies := IPAMPolicyResponse{}
-	entity, err := findEntityByName(config, name, IPAMPolicyResourceType, &ipamPolicies, "IPAMPolicies", "")
+	filter, err := workspaceFilter(config, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := findEntityByName(config, name, IPAMPolicyResourceType, &ipamPolicies, "IPAMPolicies", filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1305,522 +1648,1664 @@ func (apiClient *OneFuseAPIClient) DeleteScriptingDeployment(id int) error {
 
 // Start Naming Policies
 
-func (apiClient *OneFuseAPIClient) GetNamingPolicy(id int) (*NamingPolicy, error) {
-	log.Println("onefuse.apiClient: NamingPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
-}
-
-func (apiClient *OneFuseAPIClient) GetNamingPolicyByName(name string) (*NamingPolicy, error) {
-	log.Println("onefuse.apiClient: GetNamingPolicyByName")
+func (apiClient *OneFuseAPIClient) CreateNamingPolicy(newPolicy *NamingPolicy) (*NamingPolicy, error) {
+	log.Println("onefuse.apiClient: CreateNamingPolicy")
 
 	config := apiClient.config
 
-	namingPolicies := NamingPolicyResponse{}
-	entity, err := findEntityByName(config, name, NamingPolicyResourceType, &namingPolicies, "NamingPolicies", "")
-	if err != nil {
+	if err := prepareNamingPolicy(config, newPolicy); err != nil {
 		return nil, err
 	}
-	namingPolicy := entity.(NamingPolicy)
-	return &namingPolicy, nil
-}
 
-// End Naming Policies
+	req, err := buildPostRequest(config, NamingPolicyResourceType, newPolicy)
+	if err != nil {
+		return nil, err
+	}
 
-// Start AD Policies
+	policy := NamingPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
 
-func (apiClient *OneFuseAPIClient) GetADPolicy(id int) (*ADPolicy, error) {
-	log.Println("onefuse.apiClient: ADPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) GetADPolicyByName(name string) (*ADPolicy, error) {
-	log.Println("onefuse.apiClient: GetADPolicyByName")
+func (apiClient *OneFuseAPIClient) GetNamingPolicy(id int) (*NamingPolicy, error) {
+	log.Println("onefuse.apiClient: GetNamingPolicy")
 
 	config := apiClient.config
 
-	adPolicies := ADPolicyResponse{}
-	entity, err := findEntityByName(config, name, ADPolicyResourceType, &adPolicies, "ADPolicies", "")
-	if err != nil {
+	url := itemURL(config, NamingPolicyResourceType, id)
+
+	policy := NamingPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
 		return nil, err
 	}
-	adPolicy := entity.(ADPolicy)
-	return &adPolicy, nil
-}
-
-// End AD Policies
 
-// Start DNS Policies
-
-func (apiClient *OneFuseAPIClient) GetDNSPolicy(id int) (*DNSPolicy, error) {
-	log.Println("onefuse.apiClient: DNSPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) GetDNSPolicyByName(name string) (*DNSPolicy, error) {
-	log.Println("onefuse.apiClient: GetDNSPolicyByName")
+func (apiClient *OneFuseAPIClient) UpdateNamingPolicy(id int, updatedPolicy *NamingPolicy) (*NamingPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateNamingPolicy")
 
 	config := apiClient.config
 
-	dnsPolicies := DNSPolicyResponse{}
-	entity, err := findEntityByName(config, name, DNSPolicyResourceType, &dnsPolicies, "DNSPolicies", "")
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Naming Policy Updates Require a Name")
+	}
+
+	if err := prepareNamingPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, NamingPolicyResourceType, updatedPolicy, id)
 	if err != nil {
 		return nil, err
 	}
-	dnsPolicy := entity.(DNSPolicy)
-	return &dnsPolicy, nil
-}
 
-// End DNS Policies
+	policy := NamingPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
 
-// Start Scripting Policies
+	return &policy, nil
+}
 
-func (apiClient *OneFuseAPIClient) GetScriptingPolicy(id int) (*ScriptingPolicy, error) {
-	log.Println("onefuse.apiClient: ScriptingPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+func (apiClient *OneFuseAPIClient) DeleteNamingPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteNamingPolicy")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, NamingPolicyResourceType, id))
 }
 
-func (apiClient *OneFuseAPIClient) GetScriptingPolicyByName(name string) (*ScriptingPolicy, error) {
-	log.Println("onefuse.apiClient: GetScriptingPolicyByName")
+// Fills in the workspace and Naming Sequence URLs OneFuse expects on a Naming Policy.
+func prepareNamingPolicy(config *Config, policy *NamingPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.NamingSequence == nil && policy.NamingSequenceID != 0 {
+		namingSequence := itemURL(config, NamingSequenceResourceType, policy.NamingSequenceID)
+		policy.NamingSequence = &namingSequence
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetNamingPolicyByName(name string, workspace string) (*NamingPolicy, error) {
+	log.Println("onefuse.apiClient: GetNamingPolicyByName")
 
 	config := apiClient.config
 
-	scriptingPolicies := ScriptingPolicyResponse{}
-	entity, err := findEntityByName(config, name, ScriptingPolicyResourceType, &scriptingPolicies, "ScriptingPolicies", "")
+	namingPolicies := NamingPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
 	if err != nil {
 		return nil, err
 	}
-	scriptingPolicy := entity.(ScriptingPolicy)
-	return &scriptingPolicy, nil
+
+	entity, err := findEntityByName(config, name, NamingPolicyResourceType, &namingPolicies, "NamingPolicies", filter)
+	if err != nil {
+		return nil, err
+	}
+	namingPolicy := entity.(NamingPolicy)
+	return &namingPolicy, nil
 }
 
-// End Scripting Policies
+// End Naming Policies
 
-// Start Ansible Tower Policies
+// Start AD Policies
 
-func (apiClient *OneFuseAPIClient) GetAnsibleTowerPolicy(id int) (*AnsibleTowerPolicy, error) {
-	log.Println("onefuse.apiClient: AnsibleTowerPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+func (apiClient *OneFuseAPIClient) GetADPolicy(id int) (*ADPolicy, error) {
+	log.Println("onefuse.apiClient: GetADPolicy")
+
+	config := apiClient.config
+
+	url := itemURL(config, ADPolicyResourceType, id)
+
+	policy := ADPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) GetAnsibleTowerPolicyByName(name string) (*AnsibleTowerPolicy, error) {
-	log.Println("onefuse.apiClient: GetAnsibleTowerPolicyByName")
+func (apiClient *OneFuseAPIClient) GetADPolicyByName(name string, workspace string) (*ADPolicy, error) {
+	log.Println("onefuse.apiClient: GetADPolicyByName")
 
 	config := apiClient.config
 
-	ansibleTowerPolicies := AnsibleTowerPolicyResponse{}
-	entity, err := findEntityByName(config, name, AnsibleTowerPolicyResourceType, &ansibleTowerPolicies, "AnsibleTowerPolicies", "")
+	adPolicies := ADPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
 	if err != nil {
 		return nil, err
 	}
-	ansibleTowerPolicy := entity.(AnsibleTowerPolicy)
-	return &ansibleTowerPolicy, nil
-}
 
-// End Ansible Tower Policies
+	entity, err := findEntityByName(config, name, ADPolicyResourceType, &adPolicies, "ADPolicies", filter)
+	if err != nil {
+		return nil, err
+	}
+	adPolicy := entity.(ADPolicy)
+	return &adPolicy, nil
+}
 
-// Start ServicenowCMDB Policies
+// End AD Policies
 
-func (apiClient *OneFuseAPIClient) GetServicenowCMDBPolicy(id int) (*ServicenowCMDBPolicy, error) {
-	log.Println("onefuse.apiClient: ServicenowCMDBPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
-}
+// Start DNS Policies
 
-func (apiClient *OneFuseAPIClient) GetServicenowCMDBPolicyByName(name string) (*ServicenowCMDBPolicy, error) {
-	log.Println("onefuse.apiClient: GetServicenowCMDBPolicyByName")
+func (apiClient *OneFuseAPIClient) CreateDNSPolicy(newPolicy *DNSPolicy) (*DNSPolicy, error) {
+	log.Println("onefuse.apiClient: CreateDNSPolicy")
 
 	config := apiClient.config
 
-	servicenowCMDBPolicies := ServicenowCMDBPolicyResponse{}
-	entity, err := findEntityByName(config, name, ServicenowCMDBPolicyResourceType, &servicenowCMDBPolicies, "ServicenowCMDBPolicies", "")
+	if err := prepareDNSPolicy(config, newPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, DNSPolicyResourceType, newPolicy)
 	if err != nil {
 		return nil, err
 	}
-	servicenowCMDBPolicy := entity.(ServicenowCMDBPolicy)
-	return &servicenowCMDBPolicy, nil
+
+	policy := DNSPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-// End ServicenowCMDB Policies
+func (apiClient *OneFuseAPIClient) GetDNSPolicy(id int) (*DNSPolicy, error) {
+	log.Println("onefuse.apiClient: GetDNSPolicy")
 
-// Start vRA Policies
+	config := apiClient.config
 
-func (apiClient *OneFuseAPIClient) GetVraPolicy(id int) (*VraPolicy, error) {
-	log.Println("onefuse.apiClient: VraPolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+	url := itemURL(config, DNSPolicyResourceType, id)
+
+	policy := DNSPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) GetVraPolicyByName(name string) (*VraPolicy, error) {
-	log.Println("onefuse.apiClient: GetVraPolicyByName")
+func (apiClient *OneFuseAPIClient) UpdateDNSPolicy(id int, updatedPolicy *DNSPolicy) (*DNSPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateDNSPolicy")
 
 	config := apiClient.config
 
-	vraPolicies := VraPolicyResponse{}
-	entity, err := findEntityByName(config, name, VraPolicyResourceType, &vraPolicies, "VraPolicies", "")
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: DNS Policy Updates Require a Name")
+	}
+
+	if err := prepareDNSPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, DNSPolicyResourceType, updatedPolicy, id)
 	if err != nil {
 		return nil, err
 	}
-	vraPolicy := entity.(VraPolicy)
-	return &vraPolicy, nil
+
+	policy := DNSPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-// End vRA Policies
+func (apiClient *OneFuseAPIClient) DeleteDNSPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteDNSPolicy")
 
-// Start Static Property Set
+	config := apiClient.config
 
-func (apiClient *OneFuseAPIClient) GetStaticPropertySet(id int) (*StaticPropertySet, error) {
-	log.Println("onefuse.apiClient: GetStaticPropertySet")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
+	return doDelete(config, itemURL(config, DNSPolicyResourceType, id))
 }
 
-func (apiClient *OneFuseAPIClient) GetStaticPropertySetByName(name string) (*StaticPropertySet, error) {
-	log.Println("onefuse.apiClient: GetStaticPropertySetByName")
+// Fills in the workspace and DNS Endpoint URLs OneFuse expects on a DNS Policy.
+func prepareDNSPolicy(config *Config, policy *DNSPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: DNS Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetDNSPolicyByName(name string, workspace string) (*DNSPolicy, error) {
+	log.Println("onefuse.apiClient: GetDNSPolicyByName")
 
 	config := apiClient.config
 
-	staticPropertySets := StaticPropertySetResponse{}
-	entity, err := findEntityByName(config, name, StaticPropertySetResourceType, &staticPropertySets, "PropertySets", "")
+	dnsPolicies := DNSPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
 	if err != nil {
 		return nil, err
 	}
 
-	staticPropertySet := entity.(StaticPropertySet)
-
-	raw, err := json.Marshal(staticPropertySet.Properties)
+	entity, err := findEntityByName(config, name, DNSPolicyResourceType, &dnsPolicies, "DNSPolicies", filter)
 	if err != nil {
 		return nil, err
 	}
-	staticPropertySet.Raw = string(raw)
-
-	return &staticPropertySet, nil
+	dnsPolicy := entity.(DNSPolicy)
+	return &dnsPolicy, nil
 }
 
-// End Static Property Set
+// End DNS Policies
 
-// Start Jobs
+// Start Scripting Policies
 
-func GetJobStatus(id int, config *Config) (*JobStatus, error) {
-	log.Println("onefuse.apiClient: GetJobStatus")
+func (apiClient *OneFuseAPIClient) CreateScriptingPolicy(newPolicy *ScriptingPolicy) (*ScriptingPolicy, error) {
+	log.Println("onefuse.apiClient: CreateScriptingPolicy")
 
-	url := itemURL(config, JobStatusResourceType, id)
-	result := JobStatus{}
+	config := apiClient.config
 
-	err := doGet(config, url, &result)
+	if err := prepareScriptingPolicy(config, newPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, ScriptingPolicyResourceType, newPolicy)
 	if err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	policy := ScriptingPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-// End Jobs
+func (apiClient *OneFuseAPIClient) GetScriptingPolicy(id int) (*ScriptingPolicy, error) {
+	log.Println("onefuse.apiClient: GetScriptingPolicy")
 
-func GetJobMetaData(id int, config *Config) (*JobMetaData, error) {
-    log.Println("onefuse.apiClient: GetJobMetaData")
+	config := apiClient.config
 
-    url := itemURL(config, JobMetaDataResourceType, id)
-    result := JobMetaData{}
+	url := itemURL(config, ScriptingPolicyResourceType, id)
 
-    err := doGet(config, url, &result)
-    if err != nil {
-        return nil, err
-    }
+	policy := ScriptingPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
 
-    return &result, nil
+	return &policy, nil
 }
 
-func handleAsyncRequestAndFetchManagdObject(req *http.Request, config *Config, responseObject interface{}, httpVerb string) (jobStatus *JobStatus, err error) {
+func (apiClient *OneFuseAPIClient) UpdateScriptingPolicy(id int, updatedPolicy *ScriptingPolicy) (*ScriptingPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateScriptingPolicy")
 
-	if jobStatus, err = handleAsyncRequest(req, config, httpVerb); err != nil {
-		return
+	config := apiClient.config
+
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Scripting Policy Updates Require a Name")
 	}
 
-	url := urlFromHref(config, jobStatus.Links.ManagedObject.Href)
-	err = doGet(config, url, &responseObject)
+	if err := prepareScriptingPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, ScriptingPolicyResourceType, updatedPolicy, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return jobStatus, nil
+	policy := ScriptingPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
 }
 
-func handleAsyncRequest(req *http.Request, config *Config, httpVerb string) (jobStatus *JobStatus, err error) {
+func (apiClient *OneFuseAPIClient) DeleteScriptingPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteScriptingPolicy")
 
-	client := getHttpClient(config)
+	config := apiClient.config
 
-	res, err := client.Do(req)
+	return doDelete(config, itemURL(config, ScriptingPolicyResourceType, id))
+}
+
+// Fills in the workspace, endpoint and credential URLs OneFuse expects on a Scripting Policy.
+func prepareScriptingPolicy(config *Config, policy *ScriptingPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == nil && policy.EndpointID != 0 {
+		endpoint := itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+		policy.Endpoint = &endpoint
+	}
+
+	if policy.Credential == nil && policy.CredentialID != 0 {
+		credential := itemURL(config, CredentialResourceType, policy.CredentialID)
+		policy.Credential = &credential
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetScriptingPolicyByName(name string, workspace string) (*ScriptingPolicy, error) {
+	log.Println("onefuse.apiClient: GetScriptingPolicyByName")
+
+	config := apiClient.config
+
+	scriptingPolicies := ScriptingPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
 	if err != nil {
-		body, _ := ioutil.ReadAll(req.Body)
-		return jobStatus, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request %s %s %s", httpVerb, req.URL, body))
+		return nil, err
 	}
 
-	body, err := readResponse(res)
+	entity, err := findEntityByName(config, name, ScriptingPolicyResourceType, &scriptingPolicies, "ScriptingPolicies", filter)
 	if err != nil {
-		body, _ := ioutil.ReadAll(req.Body)
-		return jobStatus, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from %s %s %s", httpVerb, req.URL, body))
+		return nil, err
 	}
-	defer res.Body.Close()
+	scriptingPolicy := entity.(ScriptingPolicy)
+	return &scriptingPolicy, nil
+}
 
-	if err = json.Unmarshal(body, &jobStatus); err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+// End Scripting Policies
+
+// Start Ansible Tower Policies
+
+func (apiClient *OneFuseAPIClient) CreateAnsibleTowerPolicy(newPolicy *AnsibleTowerPolicy) (*AnsibleTowerPolicy, error) {
+	log.Println("onefuse.apiClient: CreateAnsibleTowerPolicy")
+
+	config := apiClient.config
+
+	if err := prepareAnsibleTowerPolicy(config, newPolicy); err != nil {
+		return nil, err
 	}
 
-	jobStatus, err = waitForJob(jobStatus.ID, config)
+	req, err := buildPostRequest(config, AnsibleTowerPolicyResourceType, newPolicy)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	if err = checkForJobErrors(jobStatus); err != nil {
+	policy := AnsibleTowerPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetAnsibleTowerPolicy(id int) (*AnsibleTowerPolicy, error) {
+	log.Println("onefuse.apiClient: GetAnsibleTowerPolicy")
+
+	config := apiClient.config
+
+	url := itemURL(config, AnsibleTowerPolicyResourceType, id)
+
+	policy := AnsibleTowerPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateAnsibleTowerPolicy(id int, updatedPolicy *AnsibleTowerPolicy) (*AnsibleTowerPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateAnsibleTowerPolicy")
+
+	config := apiClient.config
+
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Ansible Tower Policy Updates Require a Name")
+	}
+
+	if err := prepareAnsibleTowerPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, AnsibleTowerPolicyResourceType, updatedPolicy, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := AnsibleTowerPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
 		return nil, err
 	}
 
-	return jobStatus, nil
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteAnsibleTowerPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteAnsibleTowerPolicy")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, AnsibleTowerPolicyResourceType, id))
+}
+
+// Fills in the workspace and Ansible Tower Endpoint URLs OneFuse expects on an Ansible Tower Policy.
+func prepareAnsibleTowerPolicy(config *Config, policy *AnsibleTowerPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: Ansible Tower Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
+
+	if policy.Hosts == nil {
+		policy.Hosts = []string{}
+	}
+	if policy.ProvisioningJobTemplates == nil {
+		policy.ProvisioningJobTemplates = []AnsibleTowerJobTemplate{}
+	}
+	if policy.DeprovisioningJobTemplates == nil {
+		policy.DeprovisioningJobTemplates = []AnsibleTowerJobTemplate{}
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetAnsibleTowerPolicyByName(name string, workspace string) (*AnsibleTowerPolicy, error) {
+	log.Println("onefuse.apiClient: GetAnsibleTowerPolicyByName")
+
+	config := apiClient.config
+
+	ansibleTowerPolicies := AnsibleTowerPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := findEntityByName(config, name, AnsibleTowerPolicyResourceType, &ansibleTowerPolicies, "AnsibleTowerPolicies", filter)
+	if err != nil {
+		return nil, err
+	}
+	ansibleTowerPolicy := entity.(AnsibleTowerPolicy)
+	return &ansibleTowerPolicy, nil
+}
+
+// End Ansible Tower Policies
+
+// Start ServicenowCMDB Policies
+
+func (apiClient *OneFuseAPIClient) CreateServicenowCMDBPolicy(newPolicy *ServicenowCMDBPolicy) (*ServicenowCMDBPolicy, error) {
+	log.Println("onefuse.apiClient: CreateServicenowCMDBPolicy")
+
+	config := apiClient.config
+
+	if err := prepareServicenowCMDBPolicy(config, newPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, ServicenowCMDBPolicyResourceType, newPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ServicenowCMDBPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetServicenowCMDBPolicy(id int) (*ServicenowCMDBPolicy, error) {
+	log.Println("onefuse.apiClient: GetServicenowCMDBPolicy")
+
+	config := apiClient.config
+
+	url := itemURL(config, ServicenowCMDBPolicyResourceType, id)
+
+	policy := ServicenowCMDBPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateServicenowCMDBPolicy(id int, updatedPolicy *ServicenowCMDBPolicy) (*ServicenowCMDBPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateServicenowCMDBPolicy")
+
+	config := apiClient.config
+
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Policy Updates Require a Name")
+	}
+
+	if err := prepareServicenowCMDBPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, ServicenowCMDBPolicyResourceType, updatedPolicy, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ServicenowCMDBPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteServicenowCMDBPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteServicenowCMDBPolicy")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, ServicenowCMDBPolicyResourceType, id))
+}
+
+func prepareServicenowCMDBPolicy(config *Config, policy *ServicenowCMDBPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: ServiceNow CMDB Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
+
+	if policy.Relationships == nil {
+		policy.Relationships = []ServicenowCMDBRelationship{}
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetServicenowCMDBPolicyByName(name string, workspace string) (*ServicenowCMDBPolicy, error) {
+	log.Println("onefuse.apiClient: GetServicenowCMDBPolicyByName")
+
+	config := apiClient.config
+
+	servicenowCMDBPolicies := ServicenowCMDBPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := findEntityByName(config, name, ServicenowCMDBPolicyResourceType, &servicenowCMDBPolicies, "ServicenowCMDBPolicies", filter)
+	if err != nil {
+		return nil, err
+	}
+	servicenowCMDBPolicy := entity.(ServicenowCMDBPolicy)
+	return &servicenowCMDBPolicy, nil
+}
+
+// End ServicenowCMDB Policies
+
+// Start vRA Policies
+
+func (apiClient *OneFuseAPIClient) CreateVraPolicy(newPolicy *VraPolicy) (*VraPolicy, error) {
+	log.Println("onefuse.apiClient: CreateVraPolicy")
+
+	config := apiClient.config
+
+	if err := prepareVraPolicy(config, newPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, VraPolicyResourceType, newPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := VraPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetVraPolicy(id int) (*VraPolicy, error) {
+	log.Println("onefuse.apiClient: GetVraPolicy")
+
+	config := apiClient.config
+
+	url := itemURL(config, VraPolicyResourceType, id)
+
+	policy := VraPolicy{}
+	if err := doGet(config, url, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateVraPolicy(id int, updatedPolicy *VraPolicy) (*VraPolicy, error) {
+	log.Println("onefuse.apiClient: UpdateVraPolicy")
+
+	config := apiClient.config
+
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: vRA Policy Updates Require a Name")
+	}
+
+	if err := prepareVraPolicy(config, updatedPolicy); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, VraPolicyResourceType, updatedPolicy, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := VraPolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteVraPolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteVraPolicy")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, VraPolicyResourceType, id))
+}
+
+func prepareVraPolicy(config *Config, policy *VraPolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: vRA Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
+
+	if policy.InputTemplates == nil {
+		policy.InputTemplates = map[string]string{}
+	}
+
+	return nil
+}
+
+func (apiClient *OneFuseAPIClient) GetVraPolicyByName(name string, workspace string) (*VraPolicy, error) {
+	log.Println("onefuse.apiClient: GetVraPolicyByName")
+
+	config := apiClient.config
+
+	vraPolicies := VraPolicyResponse{}
+	filter, err := workspaceFilter(config, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := findEntityByName(config, name, VraPolicyResourceType, &vraPolicies, "VraPolicies", filter)
+	if err != nil {
+		return nil, err
+	}
+	vraPolicy := entity.(VraPolicy)
+	return &vraPolicy, nil
+}
+
+// End vRA Policies
+
+// Start Static Property Set
+
+func (apiClient *OneFuseAPIClient) CreateStaticPropertySet(newStaticPropertySet *StaticPropertySet) (*StaticPropertySet, error) {
+	log.Println("onefuse.apiClient: CreateStaticPropertySet")
+
+	config := apiClient.config
+
+	if err := prepareStaticPropertySet(config, newStaticPropertySet); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, StaticPropertySetResourceType, newStaticPropertySet)
+	if err != nil {
+		return nil, err
+	}
+
+	staticPropertySet := StaticPropertySet{}
+	if err = doRequestAndUnmarshal(config, req, &staticPropertySet); err != nil {
+		return nil, err
+	}
+
+	if err = decodeStaticPropertySet(&staticPropertySet); err != nil {
+		return nil, err
+	}
+	return &staticPropertySet, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetStaticPropertySet(id int) (*StaticPropertySet, error) {
+	log.Println("onefuse.apiClient: GetStaticPropertySet")
+
+	config := apiClient.config
+
+	url := itemURL(config, StaticPropertySetResourceType, id)
+
+	staticPropertySet := StaticPropertySet{}
+	if err := doGet(config, url, &staticPropertySet); err != nil {
+		return nil, err
+	}
+
+	if err := decodeStaticPropertySet(&staticPropertySet); err != nil {
+		return nil, err
+	}
+	return &staticPropertySet, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetStaticPropertySetByName(name string) (*StaticPropertySet, error) {
+	log.Println("onefuse.apiClient: GetStaticPropertySetByName")
+
+	config := apiClient.config
+
+	staticPropertySets := StaticPropertySetResponse{}
+	entity, err := findEntityByName(config, name, StaticPropertySetResourceType, &staticPropertySets, "PropertySets", "")
+	if err != nil {
+		return nil, err
+	}
+
+	staticPropertySet := entity.(StaticPropertySet)
+
+	if err = decodeStaticPropertySet(&staticPropertySet); err != nil {
+		return nil, err
+	}
+	return &staticPropertySet, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateStaticPropertySet(id int, updatedStaticPropertySet *StaticPropertySet) (*StaticPropertySet, error) {
+	log.Println("onefuse.apiClient: UpdateStaticPropertySet")
+
+	config := apiClient.config
+
+	if updatedStaticPropertySet.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Static Property Set Updates Require a Name")
+	}
+
+	if err := prepareStaticPropertySet(config, updatedStaticPropertySet); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, StaticPropertySetResourceType, updatedStaticPropertySet, id)
+	if err != nil {
+		return nil, err
+	}
+
+	staticPropertySet := StaticPropertySet{}
+	if err = doRequestAndUnmarshal(config, req, &staticPropertySet); err != nil {
+		return nil, err
+	}
+
+	if err = decodeStaticPropertySet(&staticPropertySet); err != nil {
+		return nil, err
+	}
+	return &staticPropertySet, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteStaticPropertySet(id int) error {
+	log.Println("onefuse.apiClient: DeleteStaticPropertySet")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, StaticPropertySetResourceType, id))
+}
+
+// Sends PropertiesJSON as is, so nested properties and large numbers survive the round trip.
+// Properties is only sent when PropertiesJSON is not set.
+func prepareStaticPropertySet(config *Config, staticPropertySet *StaticPropertySet) error {
+	if len(staticPropertySet.PropertiesJSON) == 0 && staticPropertySet.Properties != nil {
+		propertiesJSON, err := json.Marshal(staticPropertySet.Properties)
+		if err != nil {
+			return errors.WithMessage(err, "onefuse.apiClient: Failed to encode Static Property Set properties")
+		}
+		staticPropertySet.PropertiesJSON = propertiesJSON
+	}
+
+	var err error
+	staticPropertySet.WorkspaceURL, err = findWorkspaceURLOrDefault(config, staticPropertySet.WorkspaceURL)
+	return err
+}
+
+// Fills Properties and Raw from the properties OneFuse returned in PropertiesJSON.
+func decodeStaticPropertySet(staticPropertySet *StaticPropertySet) error {
+	staticPropertySet.Properties = nil
+	if len(staticPropertySet.PropertiesJSON) > 0 {
+		if err := json.Unmarshal(staticPropertySet.PropertiesJSON, &staticPropertySet.Properties); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to decode properties of Static Property Set '%s'", staticPropertySet.Name))
+		}
+	}
+
+	raw, err := json.Marshal(staticPropertySet.Properties)
+	if err != nil {
+		return err
+	}
+	staticPropertySet.Raw = string(raw)
+	return nil
+}
+
+// End Static Property Set
+
+// Start Jobs
+
+func GetJobStatus(id int, config *Config) (*JobStatus, error) {
+	log.Println("onefuse.apiClient: GetJobStatus")
+
+	url := itemURL(config, JobStatusResourceType, id)
+	result := JobStatus{}
+
+	err := doGet(config, url, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// End Jobs
+
+func GetJobMetaData(id int, config *Config) (*JobMetaData, error) {
+	log.Println("onefuse.apiClient: GetJobMetaData")
+
+	url := itemURL(config, JobMetaDataResourceType, id)
+	result := JobMetaData{}
+
+	err := doGet(config, url, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func handleAsyncRequestAndFetchManagdObject(req *http.Request, config *Config, responseObject interface{}, httpVerb string) (jobStatus *JobStatus, err error) {
+
+	if jobStatus, err = handleAsyncRequest(req, config, httpVerb); err != nil {
+		return
+	}
+
+	url := urlFromHref(config, jobStatus.Links.ManagedObject.Href)
+	err = doGet(config, url, &responseObject)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobStatus, nil
+}
+
+func handleAsyncRequest(req *http.Request, config *Config, httpVerb string) (jobStatus *JobStatus, err error) {
+
+	res, err := doRequest(config, req)
+	if err != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		return jobStatus, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request %s %s %s", httpVerb, req.URL, body))
+	}
+
+	if err = checkForErrors(res); err != nil {
+		return jobStatus, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		return jobStatus, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from %s %s %s", httpVerb, req.URL, body))
+	}
+	defer res.Body.Close()
+
+	if err = json.Unmarshal(body, &jobStatus); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+	}
+
+	jobStatus, err = waitForJob(jobStatus.ID, config)
+	if err != nil {
+		return
+	}
+
+	if err = checkForJobErrors(jobStatus); err != nil {
+		return nil, err
+	}
+
+	return jobStatus, nil
+}
+
+func doGet(config *Config, url string, v interface{}) (err error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request GET %s", url))
+	}
+
+	setHeaders(req, config)
+
+	res, err := doRequest(config, req)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request GET %s", url))
+	}
+
+	if err = checkForErrors(res); err != nil {
+		return err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from GET %s", url))
+	}
+	defer res.Body.Close()
+
+	if err = json.Unmarshal(body, &v); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+	}
+
+	return nil
+}
+
+// Sends a synchronous POST or PUT request and unmarshals the object OneFuse returns into v.
+func doRequestAndUnmarshal(config *Config, req *http.Request, v interface{}) (err error) {
+	res, err := doRequest(config, req)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request %s %s", req.Method, req.URL))
+	}
+
+	if err = checkForErrors(res); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Request failed %s %s", req.Method, req.URL))
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from %s %s", req.Method, req.URL))
+	}
+	defer res.Body.Close()
+
+	if err = json.Unmarshal(body, v); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+	}
+
+	return nil
+}
+
+func doDelete(config *Config, url string) error {
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request DELETE %s", url))
+	}
+
+	setHeaders(req, config)
+
+	res, err := doRequest(config, req)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request DELETE %s", url))
+	}
+	defer res.Body.Close()
+
+	return checkForErrors(res)
+}
+
+const DefaultJobTimeout = time.Hour
+const DefaultJobPollInterval = 2 * time.Second
+const DefaultJobPollMaxInterval = 30 * time.Second
+
+// Polls the job until it finishes. The wait between polls starts at the provider's job_poll_interval
+// and doubles, with jitter, up to job_poll_max_interval. Polling stops when the client's timeout
+// elapses or Terraform is interrupted.
+func waitForJob(jobID int, config *Config) (jobStatus *JobStatus, err error) {
+	timeout := config.jobTimeout
+	if timeout <= 0 {
+		timeout = DefaultJobTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(config.context(), timeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		jobStatus, err = GetJobStatus(jobID, config)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, jobWaitError(ctx, jobID, timeout)
+			}
+			return nil, err
+		}
+		log.Println(jobStatus)
+
+		if jobStatus.JobState == JobSuccess || jobStatus.JobState == JobFailed {
+			return jobStatus, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, jobWaitError(ctx, jobID, timeout)
+		case <-time.After(jobPollBackoff(config, attempt)):
+		}
+	}
+}
+
+func jobWaitError(ctx context.Context, jobID int, timeout time.Duration) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New(fmt.Sprintf("onefuse.apiClient: Timed out after %s while waiting for job %d to complete", timeout, jobID))
+	}
+	return errors.New(fmt.Sprintf("onefuse.apiClient: Interrupted while waiting for job %d to complete", jobID))
+}
+
+// Returns the wait before the next poll.
+func jobPollBackoff(config *Config, attempt int) time.Duration {
+	interval := config.pollInterval
+	if interval <= 0 {
+		interval = DefaultJobPollInterval
+	}
+	maxInterval := config.pollMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultJobPollMaxInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	return backoffWithJitter(interval, maxInterval, attempt)
+}
+
+// Resolves a workspace given by URL, ID or name to its URL. Without one, the provider's
+// default_workspace is used.
+func findWorkspaceURLOrDefault(config *Config, workspaceURL string) (string, error) {
+	if workspaceURL == "" && config.defaultWorkspaceURL != "" {
+		return config.defaultWorkspaceURL, nil
+	}
+
+	if strings.Contains(workspaceURL, "/") {
+		return workspaceURL, nil
+	}
+
+	if workspaceID, err := strconv.Atoi(workspaceURL); err == nil {
+		return itemURL(config, WorkspaceResourceType, workspaceID), nil
+	}
+
+	// Default workspace if it was not provided
+	if workspaceURL == "" {
+		workspaceID, err := findDefaultWorkspaceID(config)
+		if err != nil {
+			return "", errors.WithMessage(err, "onefuse.apiClient: Failed to find default workspace")
+		}
+		workspaceIDInt, err := strconv.Atoi(workspaceID)
+		if err != nil {
+			return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to convert Workspace ID '%s' to integer", workspaceID))
+		}
+
+		return itemURL(config, WorkspaceResourceType, workspaceIDInt), nil
+	}
+
+	workspaces := WorkspacesListResponse{}
+	entity, err := findEntityByName(config, workspaceURL, WorkspaceResourceType, &workspaces, "Workspaces", "")
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to find workspace '%s'", workspaceURL))
+	}
+	return itemURL(config, WorkspaceResourceType, entity.(Workspace).ID), nil
+}
+
+// Start Render Template
+
+func (apiClient *OneFuseAPIClient) RenderTemplate(template string, templateProperties map[string]interface{}) (*RenderTemplateResponse, error) {
+	// this API endpoint is a POST, but only so we can pass in a body to be rendered by the templating engine
+	// it behaves mostly like a GET, and doesn't create an object, just returns the rendered value.
+	log.Println("onefuse.apiClient: RenderTemplate")
+
+	config := apiClient.config
+
+	requestBody := RenderTemplateRequest{
+		Template:           template,
+		TemplateProperties: templateProperties,
+	}
+
+	var err error
+
+	var req *http.Request
+	if req, err = buildPostRequest(config, RenderTemplateType, requestBody); err != nil {
+		return nil, err
+	}
+
+	res, err := doIdempotentRequest(config, req)
+	if err != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request POST %s %s", req.URL, body))
+	}
+
+	if err = checkForErrors(res); err != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Request failed POST %s %s", req.URL, body))
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from POST %s %s", req.URL, body))
+	}
+	defer res.Body.Close()
+
+	renderTemplateResponse := RenderTemplateResponse{}
+	if err = json.Unmarshal(body, &renderTemplateResponse); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+	}
+	renderedTemplate := renderTemplateResponse
+
+	return &renderedTemplate, nil
+}
+
+// End Render Template
+
+// Start ServiceNow CMDB Deployment
+
+func (apiClient *OneFuseAPIClient) CreateServicenowCMDBDeployment(newServicenowCMDBDeployment *ServicenowCMDBDeployment) (*ServicenowCMDBDeployment, error) {
+	log.Println("onefuse.apiClient: CreateServicenowCMDBDeployment")
+
+	config := apiClient.config
+
+	var err error
+	if newServicenowCMDBDeployment.WorkspaceURL, err = findWorkspaceURLOrDefault(config, newServicenowCMDBDeployment.WorkspaceURL); err != nil {
+		return nil, err
+	}
+
+	if newServicenowCMDBDeployment.Policy == "" {
+		if newServicenowCMDBDeployment.PolicyID != 0 {
+			newServicenowCMDBDeployment.Policy = itemURL(config, ServicenowCMDBPolicyResourceType, newServicenowCMDBDeployment.PolicyID)
+		} else {
+			return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Create requires a PolicyID or Policy URL")
+		}
+	} else {
+		return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Create requires a PolicyID or Policy URL")
+	}
+
+	var req *http.Request
+	if req, err = buildPostRequest(config, ServicenowCMDBDepoloymentResourceType, newServicenowCMDBDeployment); err != nil {
+		return nil, err
+	}
+
+	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
+
+	_, err = handleAsyncRequestAndFetchManagdObject(req, config, &servicenowCMDBDeployment, "POST")
+	if err != nil {
+		return nil, err
+	}
+
+	return &servicenowCMDBDeployment, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetServicenowCMDBDeployment(id int) (*ServicenowCMDBDeployment, error) {
+	log.Println("onefuse.apiClient: GetServicenowCMDBDeployment")
+
+	config := apiClient.config
+
+	url := itemURL(config, ServicenowCMDBDepoloymentResourceType, id)
+
+	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
+	err := doGet(config, url, &servicenowCMDBDeployment)
+	if err != nil {
+		return nil, err
+	}
+	return &servicenowCMDBDeployment, err
+}
+
+func (apiClient *OneFuseAPIClient) UpdateServicenowCMDBDeployment(id int, updatedServicenowCMDBDeployment *ServicenowCMDBDeployment) (*ServicenowCMDBDeployment, error) {
+	log.Println("onefuse.apiClient: UpdateServicenowCMDBDeployment")
+
+	config := apiClient.config
+
+	var err error
+	if updatedServicenowCMDBDeployment.WorkspaceURL, err = findWorkspaceURLOrDefault(config, updatedServicenowCMDBDeployment.WorkspaceURL); err != nil {
+		return nil, err
+	}
+
+	if updatedServicenowCMDBDeployment.Policy == "" {
+		if updatedServicenowCMDBDeployment.PolicyID != 0 {
+			updatedServicenowCMDBDeployment.Policy = itemURL(config, ServicenowCMDBPolicyResourceType, updatedServicenowCMDBDeployment.PolicyID)
+		} else {
+			return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Update requires a PolicyID or Policy URL")
+		}
+	} else {
+		return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Update requires a PolicyID or Policy URL")
+	}
+
+	var req *http.Request
+	if req, err = buildPutRequest(config, ServicenowCMDBDepoloymentResourceType, updatedServicenowCMDBDeployment, id); err != nil {
+		return nil, err
+	}
+
+	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
+
+	_, err = handleAsyncRequestAndFetchManagdObject(req, config, &servicenowCMDBDeployment, "PUT")
+	if err != nil {
+		return nil, err
+	}
+
+	return &servicenowCMDBDeployment, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteServicenowCMDBDeployment(id int) error {
+	log.Println("onefuse.apiClient: DeleteServicenowCMDBDeployment")
+
+	config := apiClient.config
+
+	url := itemURL(config, ServicenowCMDBDepoloymentResourceType, id)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request DELETE %s", url))
+	}
+
+	setHeaders(req, config)
+
+	if _, err = handleAsyncRequest(req, config, "DELETE"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// End ServiceNow CMDB Deployment
+
+// Start Endpoints
+
+func (apiClient *OneFuseAPIClient) CreateEndpoint(newEndpoint *Endpoint) (*Endpoint, error) {
+	log.Println("onefuse.apiClient: CreateEndpoint")
+
+	config := apiClient.config
+
+	if err := prepareEndpoint(config, newEndpoint); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPostRequest(config, ModuleEndpointResourceType, newEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := Endpoint{}
+	if err = doRequestAndUnmarshal(config, req, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetEndpoint(id int) (*Endpoint, error) {
+	log.Println("onefuse.apiClient: GetEndpoint")
+
+	config := apiClient.config
+
+	url := itemURL(config, ModuleEndpointResourceType, id)
+
+	endpoint := Endpoint{}
+	if err := doGet(config, url, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetEndpointByName(name string, endpointType string) (*Endpoint, error) {
+	log.Println("onefuse.apiClient: GetEndpointByName")
+
+	config := apiClient.config
+
+	endpoints := EndpointsListResponse{}
+	entity, err := findEntityByName(config, name, ModuleEndpointResourceType, &endpoints, "Endpoints", ";type.exact:"+endpointType)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := entity.(Endpoint)
+	return &endpoint, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateEndpoint(id int, updatedEndpoint *Endpoint) (*Endpoint, error) {
+	log.Println("onefuse.apiClient: UpdateEndpoint")
+
+	config := apiClient.config
+
+	if updatedEndpoint.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Endpoint Updates Require a Name")
+	}
+
+	if err := prepareEndpoint(config, updatedEndpoint); err != nil {
+		return nil, err
+	}
+
+	req, err := buildPutRequest(config, ModuleEndpointResourceType, updatedEndpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := Endpoint{}
+	if err = doRequestAndUnmarshal(config, req, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteEndpoint(id int) error {
+	log.Println("onefuse.apiClient: DeleteEndpoint")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, ModuleEndpointResourceType, id))
+}
+
+func prepareEndpoint(config *Config, endpoint *Endpoint) error {
+	var err error
+	if endpoint.WorkspaceURL, err = findWorkspaceURLOrDefault(config, endpoint.WorkspaceURL); err != nil {
+		return err
+	}
+
+	if endpoint.Type == "" {
+		return errors.New("onefuse.apiClient: Endpoint requires a Type")
+	}
+
+	if endpoint.Credential == nil && endpoint.CredentialID != 0 {
+		credential := itemURL(config, CredentialResourceType, endpoint.CredentialID)
+		endpoint.Credential = &credential
+	}
+
+	return nil
+}
+
+// End Endpoints
+
+// Start Workspaces
+
+func (apiClient *OneFuseAPIClient) CreateWorkspace(newWorkspace *Workspace) (*Workspace, error) {
+	log.Println("onefuse.apiClient: CreateWorkspace")
+
+	config := apiClient.config
+
+	req, err := buildPostRequest(config, WorkspaceResourceType, newWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := Workspace{}
+	if err = doRequestAndUnmarshal(config, req, &workspace); err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetWorkspace(id int) (*Workspace, error) {
+	log.Println("onefuse.apiClient: GetWorkspace")
+
+	config := apiClient.config
+
+	url := itemURL(config, WorkspaceResourceType, id)
+
+	workspace := Workspace{}
+	if err := doGet(config, url, &workspace); err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (apiClient *OneFuseAPIClient) GetWorkspaceByName(name string) (*Workspace, error) {
+	log.Println("onefuse.apiClient: GetWorkspaceByName")
+
+	config := apiClient.config
+
+	workspaces := WorkspacesListResponse{}
+	entity, err := findEntityByName(config, name, WorkspaceResourceType, &workspaces, "Workspaces", "")
+	if err != nil {
+		return nil, err
+	}
+	workspace := entity.(Workspace)
+	return &workspace, nil
+}
+
+func (apiClient *OneFuseAPIClient) UpdateWorkspace(id int, updatedWorkspace *Workspace) (*Workspace, error) {
+	log.Println("onefuse.apiClient: UpdateWorkspace")
+
+	config := apiClient.config
+
+	if updatedWorkspace.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Workspace Updates Require a Name")
+	}
+
+	req, err := buildPutRequest(config, WorkspaceResourceType, updatedWorkspace, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := Workspace{}
+	if err = doRequestAndUnmarshal(config, req, &workspace); err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (apiClient *OneFuseAPIClient) DeleteWorkspace(id int) error {
+	log.Println("onefuse.apiClient: DeleteWorkspace")
+
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, WorkspaceResourceType, id))
 }
 
-func doGet(config *Config, url string, v interface{}) (err error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request GET %s", url))
-	}
+// End Workspaces
 
-	setHeaders(req, config)
+// Start Credentials
 
-	client := getHttpClient(config)
-	res, err := client.Do(req)
-	if err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request GET %s", url))
-	}
+func (apiClient *OneFuseAPIClient) CreateCredential(newCredential *Credential) (*Credential, error) {
+	log.Println("onefuse.apiClient: CreateCredential")
 
-	if err = checkForErrors(res); err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Request failed GET %s", url))
+	config := apiClient.config
+
+	if err := prepareCredential(config, newCredential); err != nil {
+		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(res.Body)
+	req, err := buildPostRequest(config, CredentialResourceType, newCredential)
 	if err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from GET %s", url))
+		return nil, err
 	}
-	defer res.Body.Close()
 
-	if err = json.Unmarshal(body, &v); err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
+	credential := Credential{}
+	if err = doRequestAndUnmarshal(config, req, &credential); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &credential, nil
 }
 
-func waitForJob(jobID int, config *Config) (jobStatus *JobStatus, err error) {
-	jobStatusDescription := ""
-	PollingTimeoutMS := 3600000
-	PollingIntervalMS := 5000
-	startTime := time.Now()
-	for jobStatusDescription != JobSuccess && jobStatusDescription != JobFailed {
-		jobStatus, err = GetJobStatus(jobID, config)
-		if err != nil {
-			return nil, err
-		}
+func (apiClient *OneFuseAPIClient) GetCredential(id int) (*Credential, error) {
+	log.Println("onefuse.apiClient: GetCredential")
 
-		jobStatusDescription = jobStatus.JobState
-		log.Println(jobStatus)
+	config := apiClient.config
 
-		time.Sleep(time.Duration(PollingIntervalMS) * time.Millisecond)
-		if time.Since(startTime) > (time.Duration(PollingTimeoutMS) * time.Millisecond) {
-			return nil, errors.New("Timed out while waiting for job to complete.")
-		}
+	url := itemURL(config, CredentialResourceType, id)
+
+	credential := Credential{}
+	if err := doGet(config, url, &credential); err != nil {
+		return nil, err
 	}
-	return jobStatus, nil
+
+	return &credential, nil
 }
 
-func findWorkspaceURLOrDefault(config *Config, workspaceURL string) (string, error) {
-	// Default workspace if it was not provided
-	if workspaceURL == "" {
-		workspaceID, err := findDefaultWorkspaceID(config)
-		if err != nil {
-			return "", errors.WithMessage(err, "onefuse.apiClient: Failed to find default workspace")
-		}
-		workspaceIDInt, err := strconv.Atoi(workspaceID)
-		if err != nil {
-			return "", errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to convert Workspace ID '%s' to integer", workspaceID))
-		}
+func (apiClient *OneFuseAPIClient) GetCredentialByName(name string) (*Credential, error) {
+	log.Println("onefuse.apiClient: GetCredentialByName")
+
+	config := apiClient.config
 
-		workspaceURL = itemURL(config, WorkspaceResourceType, workspaceIDInt)
+	credentials := CredentialsListResponse{}
+	entity, err := findEntityByName(config, name, CredentialResourceType, &credentials, "Credentials", "")
+	if err != nil {
+		return nil, err
 	}
-	return workspaceURL, nil
+	credential := entity.(Credential)
+	return &credential, nil
 }
 
-// Start Render Template
-
-func (apiClient *OneFuseAPIClient) RenderTemplate(template string, templateProperties map[string]interface{}) (*RenderTemplateResponse, error) {
-	// this API endpoint is a POST, but only so we can pass in a body to be rendered by the templating engine
-	// it behaves mostly like a GET, and doesn't create an object, just returns the rendered value.
-	log.Println("onefuse.apiClient: RenderTemplate")
+func (apiClient *OneFuseAPIClient) UpdateCredential(id int, updatedCredential *Credential) (*Credential, error) {
+	log.Println("onefuse.apiClient: UpdateCredential")
 
 	config := apiClient.config
 
-	requestBody := RenderTemplateRequest{
-		Template:           template,
-		TemplateProperties: templateProperties,
+	if updatedCredential.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Credential Updates Require a Name")
 	}
 
-	var err error
-
-	var req *http.Request
-	if req, err = buildPostRequest(config, RenderTemplateType, requestBody); err != nil {
+	if err := prepareCredential(config, updatedCredential); err != nil {
 		return nil, err
 	}
 
-	client := getHttpClient(config)
-
-	res, err := client.Do(req)
+	req, err := buildPutRequest(config, CredentialResourceType, updatedCredential, id)
 	if err != nil {
-		body, _ := ioutil.ReadAll(req.Body)
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to do request POST %s %s", req.URL, body))
+		return nil, err
 	}
 
-	if err = checkForErrors(res); err != nil {
-		body, _ := ioutil.ReadAll(req.Body)
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Request failed POST %s %s", req.URL, body))
+	credential := Credential{}
+	if err = doRequestAndUnmarshal(config, req, &credential); err != nil {
+		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		body, _ := ioutil.ReadAll(req.Body)
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read response body from POST %s %s", req.URL, body))
-	}
-	defer res.Body.Close()
+	return &credential, nil
+}
 
-	renderTemplateResponse := RenderTemplateResponse{}
-	if err = json.Unmarshal(body, &renderTemplateResponse); err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to unmarshal response %s", string(body)))
-	}
-	renderedTemplate := renderTemplateResponse
+func (apiClient *OneFuseAPIClient) DeleteCredential(id int) error {
+	log.Println("onefuse.apiClient: DeleteCredential")
 
-	return &renderedTemplate, nil
+	config := apiClient.config
+
+	return doDelete(config, itemURL(config, CredentialResourceType, id))
 }
 
-// End Render Template
+func prepareCredential(config *Config, credential *Credential) error {
+	var err error
+	credential.WorkspaceURL, err = findWorkspaceURLOrDefault(config, credential.WorkspaceURL)
+	return err
+}
 
-// Start ServiceNow CMDB Deployment
+// End Credentials
 
-func (apiClient *OneFuseAPIClient) CreateServicenowCMDBDeployment(newServicenowCMDBDeployment *ServicenowCMDBDeployment) (*ServicenowCMDBDeployment, error) {
-	log.Println("onefuse.apiClient: CreateServicenowCMDBDeployment")
+// Start Module Policies
+
+func (apiClient *OneFuseAPIClient) CreateModulePolicy(newPolicy *ModulePolicy) (*ModulePolicy, error) {
+	log.Println("onefuse.apiClient: CreateModulePolicy")
 
 	config := apiClient.config
 
-	var err error
-	if newServicenowCMDBDeployment.WorkspaceURL, err = findWorkspaceURLOrDefault(config, newServicenowCMDBDeployment.WorkspaceURL); err != nil {
+	if err := prepareModulePolicy(config, newPolicy); err != nil {
 		return nil, err
 	}
 
-	if newServicenowCMDBDeployment.Policy == "" {
-		if newServicenowCMDBDeployment.PolicyID != 0 {
-			newServicenowCMDBDeployment.Policy = itemURL(config, WorkspaceResourceType, newServicenowCMDBDeployment.PolicyID)
-		} else {
-			return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Create requires a PolicyID or Policy URL")
-		}
-	} else {
-		return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Create requires a PolicyID or Policy URL")
-	}
-
-	var req *http.Request
-	if req, err = buildPostRequest(config, ServicenowCMDBDepoloymentResourceType, newServicenowCMDBDeployment); err != nil {
+	req, err := buildPostRequest(config, ModulePolicyResourceType, newPolicy)
+	if err != nil {
 		return nil, err
 	}
 
-	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
-
-	_, err = handleAsyncRequestAndFetchManagdObject(req, config, &servicenowCMDBDeployment, "POST")
-	if err != nil {
+	policy := ModulePolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
 		return nil, err
 	}
 
-	return &servicenowCMDBDeployment, nil
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) GetServicenowCMDBDeployment(id int) (*ServicenowCMDBDeployment, error) {
-	log.Println("onefuse.apiClient: GetServicenowCMDBDeployment")
+func (apiClient *OneFuseAPIClient) GetModulePolicy(id int) (*ModulePolicy, error) {
+	log.Println("onefuse.apiClient: GetModulePolicy")
 
 	config := apiClient.config
 
-	url := itemURL(config, ServicenowCMDBDepoloymentResourceType, id)
+	url := itemURL(config, ModulePolicyResourceType, id)
 
-	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
-	err := doGet(config, url, &servicenowCMDBDeployment)
-	if err != nil {
+	policy := ModulePolicy{}
+	if err := doGet(config, url, &policy); err != nil {
 		return nil, err
 	}
-	return &servicenowCMDBDeployment, err
+
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) UpdateServicenowCMDBDeployment(id int, updatedServicenowCMDBDeployment *ServicenowCMDBDeployment) (*ServicenowCMDBDeployment, error) {
-	log.Println("onefuse.apiClient: UpdateServicenowCMDBDeployment")
+func (apiClient *OneFuseAPIClient) UpdateModulePolicy(id int, updatedPolicy *ModulePolicy) (*ModulePolicy, error) {
+	log.Println("onefuse.apiClient: UpdateModulePolicy")
 
 	config := apiClient.config
 
-	var err error
-	if updatedServicenowCMDBDeployment.WorkspaceURL, err = findWorkspaceURLOrDefault(config, updatedServicenowCMDBDeployment.WorkspaceURL); err != nil {
-		return nil, err
+	if updatedPolicy.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Module Policy Updates Require a Name")
 	}
 
-	if updatedServicenowCMDBDeployment.Policy == "" {
-		if updatedServicenowCMDBDeployment.PolicyID != 0 {
-			updatedServicenowCMDBDeployment.Policy = itemURL(config, WorkspaceResourceType, updatedServicenowCMDBDeployment.PolicyID)
-		} else {
-			return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Update requires a PolicyID or Policy URL")
-		}
-	} else {
-		return nil, errors.New("onefuse.apiClient: ServiceNow CMDB Deployment Update requires a PolicyID or Policy URL")
+	if err := prepareModulePolicy(config, updatedPolicy); err != nil {
+		return nil, err
 	}
 
-	var req *http.Request
-	if req, err = buildPutRequest(config, ServicenowCMDBDepoloymentResourceType, updatedServicenowCMDBDeployment, id); err != nil {
+	req, err := buildPutRequest(config, ModulePolicyResourceType, updatedPolicy, id)
+	if err != nil {
 		return nil, err
 	}
 
-	servicenowCMDBDeployment := ServicenowCMDBDeployment{}
-
-	_, err = handleAsyncRequestAndFetchManagdObject(req, config, &servicenowCMDBDeployment, "PUT")
-	if err != nil {
+	policy := ModulePolicy{}
+	if err = doRequestAndUnmarshal(config, req, &policy); err != nil {
 		return nil, err
 	}
 
-	return &servicenowCMDBDeployment, nil
+	return &policy, nil
 }
 
-func (apiClient *OneFuseAPIClient) DeleteServicenowCMDBDeployment(id int) error {
-	log.Println("onefuse.apiClient: DeleteServicenowCMDBDeployment")
+func (apiClient *OneFuseAPIClient) DeleteModulePolicy(id int) error {
+	log.Println("onefuse.apiClient: DeleteModulePolicy")
 
 	config := apiClient.config
 
-	url := itemURL(config, ServicenowCMDBDepoloymentResourceType, id)
+	return doDelete(config, itemURL(config, ModulePolicyResourceType, id))
+}
 
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to create request DELETE %s", url))
+func prepareModulePolicy(config *Config, policy *ModulePolicy) error {
+	var err error
+	if policy.WorkspaceURL, err = findWorkspaceURLOrDefault(config, policy.WorkspaceURL); err != nil {
+		return err
 	}
 
-	setHeaders(req, config)
+	if policy.Endpoint == "" {
+		if policy.EndpointID == 0 {
+			return errors.New("onefuse.apiClient: Module Policy requires an EndpointID or Endpoint URL")
+		}
+		policy.Endpoint = itemURL(config, ModuleEndpointResourceType, policy.EndpointID)
+	}
 
-	if _, err = handleAsyncRequest(req, config, "DELETE"); err != nil {
-		return err
+	for _, credentialID := range policy.CredentialIDs {
+		policy.Credentials = append(policy.Credentials, itemURL(config, CredentialResourceType, credentialID))
+	}
+	if policy.Credentials == nil {
+		policy.Credentials = []string{}
 	}
 
 	return nil
 }
 
-// End ServiceNow CMDB Deployment
-
-// Start Module Policies
-
-func (apiClient *OneFuseAPIClient) GetModulePolicy(id int) (*ModulePolicy, error) {
-	log.Println("onefuse.apiClient: GetModulePolicy")
-	return nil, errors.New("onefuse.apiClient: Not implemented yet")
-}
-
-func (apiClient *OneFuseAPIClient) GetModulePolicyByName(name string) (*ModulePolicy, error) {
+func (apiClient *OneFuseAPIClient) GetModulePolicyByName(name string, workspace string) (*ModulePolicy, error) {
 	log.Println("onefuse.apiClient: GetModulePolicyByName")
 
 	config := apiClient.config
 
 	modulePolicies := ModulePolicyResponse{}
-	entity, err := findEntityByName(config, name, ModulePolicyResourceType, &modulePolicies, "ModulePolicies", "")
+	filter, err := workspaceFilter(config, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := findEntityByName(config, name, ModulePolicyResourceType, &modulePolicies, "ModulePolicies", filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1844,7 +3329,7 @@ func (apiClient *OneFuseAPIClient) CreateModuleDeployment(newModuleDeployment *M
 
 	if newModuleDeployment.Policy == "" {
 		if newModuleDeployment.PolicyID != 0 {
-			newModuleDeployment.Policy = itemURL(config, WorkspaceResourceType, newModuleDeployment.PolicyID)
+			newModuleDeployment.Policy = itemURL(config, ModulePolicyResourceType, newModuleDeployment.PolicyID)
 		} else {
 			return nil, errors.New("onefuse.apiClient: Module Deployment Create requires a PolicyID or Policy URL")
 		}
@@ -1894,7 +3379,7 @@ func (apiClient *OneFuseAPIClient) UpdateModuleDeployment(id int, updatedModuleD
 
 	if updatedModuleDeployment.Policy == "" {
 		if updatedModuleDeployment.PolicyID != 0 {
-			updatedModuleDeployment.Policy = itemURL(config, WorkspaceResourceType, updatedModuleDeployment.PolicyID)
+			updatedModuleDeployment.Policy = itemURL(config, ModulePolicyResourceType, updatedModuleDeployment.PolicyID)
 		} else {
 			return nil, errors.New("onefuse.apiClient: Module Deployment Update requires a PolicyID or Policy URL")
 		}
@@ -1940,54 +3425,50 @@ func (apiClient *OneFuseAPIClient) DeleteModuleDeployment(id int) error {
 
 // End Module Deployment
 
+const DefaultWorkspaceName = "Default"
+
 func findDefaultWorkspaceID(config *Config) (workspaceID string, err error) {
 	fmt.Println("onefuse.findDefaultWorkspaceID")
 
-	filter := "filter=name.exact:Default"
-	url := fmt.Sprintf("%s?%s", collectionURL(config, WorkspaceResourceType), filter)
-
-	req, clientErr := http.NewRequest("GET", url, nil)
-	if clientErr != nil {
-		err = errors.WithMessage(clientErr, fmt.Sprintf("onefuse.findDefaultWorkspaceID: Failed to make request GET %s", url))
+	workspaces := WorkspacesListResponse{}
+	entity, err := findEntityByName(config, DefaultWorkspaceName, WorkspaceResourceType, &workspaces, "Workspaces", "")
+	if err != nil {
+		err = errors.WithMessage(err, "onefuse.findDefaultWorkspaceID: Failed to find default workspace!")
 		return
 	}
+	workspaceID = strconv.Itoa(entity.(Workspace).ID)
+	return
+}
 
-	setHeaders(req, config)
-
-	client := getHttpClient(config)
-	res, clientErr := client.Do(req)
-	if clientErr != nil {
-		err = errors.WithMessage(clientErr, fmt.Sprintf("onefuse.findDefaultWorkspaceID: Failed to do request GET %s", url))
-		return
+// Returns the filter restricting a collection to the workspace given by URL, ID or name.
+// Without a workspace, the collection is not filtered.
+func workspaceFilter(config *Config, workspace string) (string, error) {
+	if workspace == "" {
+		return "", nil
 	}
 
-	body, err := readResponse(res)
+	workspaceURL, err := findWorkspaceURLOrDefault(config, workspace)
 	if err != nil {
-		return
+		return "", err
 	}
-	defer res.Body.Close()
-
-	var data WorkspacesListResponse
-	json.Unmarshal(body, &data)
-
-	workspaces := data.Embedded.Workspaces
-	if len(workspaces) == 0 {
-		err = errors.WithMessage(clientErr, "onefuse.findDefaultWorkspaceID: Failed to find default workspace!")
-		return
+	workspaceID, err := LinkRef{Href: workspaceURL}.ID()
+	if err != nil {
+		return "", err
 	}
-	workspaceID = strconv.Itoa(workspaces[0].ID)
-	return
+	return fmt.Sprintf(";workspace.id:%d", workspaceID), nil
 }
 
-// Finds an entity on OneFuse of type "resourceType" with name "name", using the supplied "collectionResponse" interface
+// Finds the entity on OneFuse of type "resourceType" named exactly "name", using the supplied "collectionResponse" interface
 // embeddedStructFieldName as the name of the embedded inner collection name.
 // Additional filters to the collection will be appened to the name filter in the URL.
+// It is an error for more than one entity to match.
 func findEntityByName(config *Config, name string, resourceType string, collectionResponse interface{},
 	embeddedStructFieldName string, additionalFilters string) (interface{}, error) {
 
-	url := fmt.Sprintf("%s?filter=name:%s%s", collectionURL(config, resourceType), name, additionalFilters)
+	filter := url.QueryEscape(fmt.Sprintf("name.exact:%s%s", name, additionalFilters))
+	requestURL := fmt.Sprintf("%s?filter=%s", collectionURL(config, resourceType), filter)
 
-	err := doGet(config, url, &collectionResponse)
+	err := doGet(config, requestURL, &collectionResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -1999,22 +3480,147 @@ func findEntityByName(config *Config, name string, resourceType string, collecti
 
 	collectionField := reflect.Indirect(reflect.ValueOf(embedded)).FieldByName(embeddedStructFieldName)
 
-	if collectionField.Len() < 1 {
+	// Names are compared again in case OneFuse matched them loosely
+	var matches []reflect.Value
+	for i := 0; i < collectionField.Len(); i++ {
+		if candidate := collectionField.Index(i); candidate.FieldByName("Name").String() == name {
+			matches = append(matches, candidate)
+		}
+	}
+
+	if len(matches) < 1 {
 		return nil, errors.New(fmt.Sprintf("onefuse.apiClient: Could not find %s '%s'!", resourceType, name))
 	}
+	if len(matches) > 1 {
+		candidates := make([]string, len(matches))
+		for i, match := range matches {
+			candidates[i] = describeEntity(match)
+		}
+		return nil, errors.New(fmt.Sprintf("onefuse.apiClient: Found %d %s named '%s', expected one: %s",
+			len(matches), resourceType, name, strings.Join(candidates, ", ")))
+	}
+
+	return matches[0].Interface(), nil
+}
 
-	entity := collectionField.Index(0).Interface()
+// Describes an entity found by findEntityByName by its ID and workspace, which tell entities of the same name apart.
+func describeEntity(entity reflect.Value) string {
+	description := fmt.Sprintf("ID %d", entity.FieldByName("ID").Int())
 
-	return entity, err
+	links := reflect.Indirect(entity.FieldByName("Links"))
+	if !links.IsValid() || !links.FieldByName("Workspace").IsValid() {
+		return description
+	}
+	if workspace, ok := links.FieldByName("Workspace").Interface().(LinkRef); ok && workspace.Href != "" {
+		if workspace.Title != "" {
+			return fmt.Sprintf("%s in workspace '%s'", description, workspace.Title)
+		}
+		return fmt.Sprintf("%s in workspace %s", description, workspace.Href)
+	}
+	return description
 }
 
+const DefaultMaxIdleConns = 100
+const DefaultMaxConnsPerHost = 0
+
+// Returns the provider instance's shared client. Configs built outside of configureProvider
+// do not have one and get a client of their own.
 func getHttpClient(config *Config) *http.Client {
+	if config.httpClient != nil {
+		return config.httpClient
+	}
+	return newHttpClient(config, DefaultMaxIdleConns, DefaultMaxConnsPerHost, nil)
+}
+
+// Builds a client whose transport keeps connections to OneFuse alive between requests.
+// A nil proxyURL falls back to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+func newHttpClient(config *Config, maxIdleConns int, maxConnsPerHost int, proxyURL *url.URL) *http.Client {
+	tlsConfig := config.tlsConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{InsecureSkipVerify: !config.verifySSL}
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if proxyURL != nil {
+		proxy = http.ProxyURL(proxyURL)
+	}
+
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: !config.verifySSL},
+		Proxy:               proxy,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConns,
+		MaxConnsPerHost:     maxConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
 	}
 	return &http.Client{Transport: tr}
 }
 
+// Sends the request with the provider's credentials attached, retrying transient failures.
+func doRequest(config *Config, req *http.Request) (*http.Response, error) {
+	return doRequestWithRetry(config, req, isIdempotentMethod(req.Method))
+}
+
+// Like doRequest, for POSTs that only read data, such as rendering a template, and can be repeated freely.
+func doIdempotentRequest(config *Config, req *http.Request) (*http.Response, error) {
+	return doRequestWithRetry(config, req, true)
+}
+
+// Sends the request once. When a session token has expired, the credentials are exchanged
+// again and the request is replayed.
+func sendRequest(config *Config, req *http.Request) (*http.Response, error) {
+	auth := config.auth
+	if auth == nil {
+		auth = &apiAuth{method: AuthMethodBasic}
+	}
+
+	if err := auth.authorize(config, req); err != nil {
+		return nil, err
+	}
+
+	client := getHttpClient(config)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized || auth.method != AuthMethodSession {
+		return res, nil
+	}
+
+	retry, err := rewindRequest(req)
+	if err != nil {
+		return res, nil
+	}
+	res.Body.Close()
+
+	log.Printf("onefuse.apiClient: Session token rejected for %s %s, requesting a new one", req.Method, req.URL)
+	auth.invalidate()
+	if err := auth.authorize(config, retry); err != nil {
+		return nil, err
+	}
+
+	return client.Do(retry)
+}
+
+// Returns a copy of the request with a fresh body so it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("onefuse.apiClient: Request body cannot be replayed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry.Body = body
+	return retry, nil
+}
+
 func readResponse(res *http.Response) (bytes []byte, err error) {
 	err = checkForErrors(res)
 	if err != nil {
@@ -2025,28 +3631,29 @@ func readResponse(res *http.Response) (bytes []byte, err error) {
 	return
 }
 
+// Returns an *APIError for 4xx and 5xx responses.
 func checkForErrors(res *http.Response) error {
-	if res.StatusCode >= 500 {
-		b, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		return errors.New(string(b))
-	} else if res.StatusCode >= 400 {
-		b, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		return errors.New(string(b))
+	if res.StatusCode < 400 {
+		return nil
 	}
-	return nil
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("onefuse.apiClient: Failed to read error response with status %d", res.StatusCode))
+	}
+	return newAPIError(res, b)
 }
 
 func checkForJobErrors(jobStatus *JobStatus) error {
 	if jobStatus.JobState != JobSuccess {
-		return errors.New(fmt.Sprintf("Job %s (%d) failed with message %v", jobStatus.JobType, jobStatus.ID, *jobStatus.ErrorDetails.Errors))
+		var messages []string
+		if jobStatus.ErrorDetails != nil && jobStatus.ErrorDetails.Errors != nil {
+			for _, detail := range *jobStatus.ErrorDetails.Errors {
+				messages = append(messages, detail.Message)
+			}
+		}
+		return errors.New(fmt.Sprintf("Job %s (%d) failed with message %v (job tracking ID %s)", jobStatus.JobType, jobStatus.ID, messages, jobStatus.JobTrackingID))
 	}
 	return nil
 }
@@ -2064,7 +3671,6 @@ func setHeaders(req *http.Request, config *Config) {
 	setStandardHeaders(req)
 	req.Header.Add("Host", fmt.Sprintf("%s:%s", config.address, config.port))
 	req.Header.Add("SOURCE", "Terraform")
-	req.SetBasicAuth(config.user, config.password)
 }
 
 func collectionURL(config *Config, resourceType string) string {
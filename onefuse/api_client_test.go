@@ -0,0 +1,262 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudboltsoftware/terraform-provider-onefuse/onefuse/onefusetest"
+)
+
+func TestHttpClientReusesConnections(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	config.verifySSL = false
+	config.httpClient = newHttpClient(&config, DefaultMaxIdleConns, DefaultMaxConnsPerHost, nil)
+
+	apiClient := config.NewOneFuseApiClient()
+	for i := 0; i < 10; i++ {
+		if _, err := apiClient.GetMicrosoftADPolicy(1); err != nil {
+			t.Errorf("Error getting policy: '%s'", err)
+			return
+		}
+	}
+
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Errorf("Expected 1 connection to be opened for 10 requests but got %d", n)
+	}
+}
+
+func TestHttpClientProxyURL(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the OneFuse endpoint
+		if r.URL.Host == "onefuse.example.com:443" {
+			atomic.AddInt32(&proxied, 1)
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("Error parsing proxy URL: '%s'", err)
+	}
+
+	config := NewConfig("http", "onefuse.example.com", "443", "admin", "admin", true)
+	config.httpClient = newHttpClient(&config, DefaultMaxIdleConns, DefaultMaxConnsPerHost, proxyURL)
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy through proxy: '%s'", err)
+	}
+	if proxied != 1 {
+		t.Errorf("Expected the request to go through the proxy")
+	}
+}
+
+func newJobStatusTestServer(pendingPolls int32) (*httptest.Server, *int32) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "Pending"
+		if atomic.AddInt32(&polls, 1) > pendingPolls {
+			state = JobSuccess
+		}
+		json.NewEncoder(w).Encode(JobStatus{ID: 1, JobState: state})
+	}))
+	return server, &polls
+}
+
+func TestWaitForJobPollsUntilFinished(t *testing.T) {
+	server, polls := newJobStatusTestServer(3)
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	config.pollInterval = time.Millisecond
+	config.pollMaxInterval = 4 * time.Millisecond
+
+	jobStatus, err := waitForJob(1, &config)
+	if err != nil {
+		t.Errorf("Error waiting for job: '%s'", err)
+		return
+	}
+	if jobStatus.JobState != JobSuccess {
+		t.Errorf("Bad job state; expected '%s' but got '%s'", JobSuccess, jobStatus.JobState)
+	}
+	if *polls != 4 {
+		t.Errorf("Expected 4 polls but got %d", *polls)
+	}
+}
+
+func TestWaitForJobTimeout(t *testing.T) {
+	server, _ := newJobStatusTestServer(1000)
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	config.pollInterval = time.Millisecond
+	config.pollMaxInterval = time.Millisecond
+
+	apiClient := config.NewOneFuseApiClient().WithTimeout(20 * time.Millisecond)
+
+	_, err := waitForJob(1, apiClient.config)
+	if err == nil || !strings.Contains(err.Error(), "Timed out") {
+		t.Errorf("Expected a timeout error but got '%v'", err)
+	}
+}
+
+func TestWaitForJobInterrupted(t *testing.T) {
+	server, _ := newJobStatusTestServer(1000)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	config := configForTestServer(t, server)
+	config.pollInterval = time.Millisecond
+	config.pollMaxInterval = time.Millisecond
+	config.stopContext = func() context.Context { return ctx }
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := waitForJob(1, &config)
+	if err == nil || !strings.Contains(err.Error(), "Interrupted") {
+		t.Errorf("Expected an interrupted error but got '%v'", err)
+	}
+}
+
+func TestJobPollBackoff(t *testing.T) {
+	config := Config{pollInterval: time.Second, pollMaxInterval: 8 * time.Second}
+
+	tables := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{10, 8 * time.Second},
+	}
+
+	for _, table := range tables {
+		wait := jobPollBackoff(&config, table.attempt)
+		if wait < table.max/2 || wait > table.max {
+			t.Errorf("Bad backoff for attempt %d; expected between %s and %s but got %s", table.attempt, table.max/2, table.max, wait)
+		}
+	}
+}
+
+func TestFailedJobReportsTrackingID(t *testing.T) {
+	server := onefusetest.NewServer()
+	defer server.Close()
+	server.FailJobs(DNSReservationResourceType, "Zone example.com does not exist")
+
+	config := fakeServerConfig(server)
+	_, err := config.NewOneFuseApiClient().CreateDNSReservation(&DNSReservation{Name: "host", PolicyID: 1})
+	if err == nil {
+		t.Fatalf("Expected an error from the failed job")
+	}
+	if !strings.Contains(err.Error(), "Zone example.com does not exist") || !strings.Contains(err.Error(), "fake-job-1") {
+		t.Errorf("Expected the job's message and tracking ID in '%s'", err)
+	}
+}
+
+func TestFindEntityByNameMatchesExactly(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		// Answers like a OneFuse that matches names loosely
+		w.Write([]byte(`{"_embedded": {"dnsPolicies": [
+			{"id": 1, "name": "prod-legacy"},
+			{"id": 2, "name": "prod & test"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+
+	policy, err := config.NewOneFuseApiClient().GetDNSPolicyByName("prod & test", "")
+	if err != nil {
+		t.Fatalf("Error getting DNS Policy by name: '%s'", err)
+	}
+	if policy.ID != 2 {
+		t.Errorf("Expected DNS Policy 2 but got %d", policy.ID)
+	}
+	if rawQuery != "filter=name.exact%3Aprod+%26+test" {
+		t.Errorf("Bad query for DNS Policy; got '%s'", rawQuery)
+	}
+
+	if _, err = config.NewOneFuseApiClient().GetDNSPolicyByName("prod", ""); err == nil {
+		t.Error("Expected an error getting DNS Policy 'prod' when only 'prod-legacy' exists")
+	}
+}
+
+func TestFindEntityByNameAmbiguous(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	dnsEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_DNS_ENDPOINT_ID", "1"))
+
+	var policies []*DNSPolicy
+	for _, workspaceName := range []string{"tfAmbiguousWorkspace01", "tfAmbiguousWorkspace02"} {
+		workspace, err := apiClient.CreateWorkspace(&Workspace{Name: workspaceName})
+		if err != nil {
+			t.Fatalf("Error creating Workspace: '%s'", err)
+		}
+		defer apiClient.DeleteWorkspace(workspace.ID)
+
+		policy, err := apiClient.CreateDNSPolicy(&DNSPolicy{
+			Name:         "tfAmbiguousPolicy",
+			EndpointID:   dnsEndpointID,
+			Zones:        []string{"example.com"},
+			Records:      []DNSPolicyRecord{{Type: "a", Name: "{{ hostname }}", Value: "{{ ipAddress }}"}},
+			WorkspaceURL: workspaceName,
+		})
+		if err != nil {
+			t.Fatalf("Error creating DNS Policy: '%s'", err)
+		}
+		defer apiClient.DeleteDNSPolicy(policy.ID)
+		policies = append(policies, policy)
+	}
+
+	_, err := apiClient.GetDNSPolicyByName("tfAmbiguousPolicy", "")
+	if err == nil {
+		t.Fatal("Expected an error getting an ambiguous DNS Policy by name")
+	}
+	for _, policy := range policies {
+		if !strings.Contains(err.Error(), fmt.Sprintf("ID %d in workspace", policy.ID)) {
+			t.Errorf("Expected the error to list DNS Policy %d but got '%s'", policy.ID, err)
+		}
+	}
+
+	policy, err := apiClient.GetDNSPolicyByName("tfAmbiguousPolicy", "tfAmbiguousWorkspace02")
+	if err != nil {
+		t.Fatalf("Error getting DNS Policy by name in a workspace: '%s'", err)
+	}
+	if policy.ID != policies[1].ID {
+		t.Errorf("Expected DNS Policy %d but got %d", policies[1].ID, policy.ID)
+	}
+}
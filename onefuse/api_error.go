@@ -0,0 +1,115 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Error body returned by OneFuse for a failed request.
+type APIErrorDetails struct {
+	Code   int    `json:"code,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	Errors []struct {
+		Message string `json:"message,omitempty"`
+	} `json:"errors,omitempty"`
+	JobTrackingID string `json:"jobTrackingId,omitempty"`
+}
+
+// An error response from the OneFuse API. Use errors.As to get at it through the messages
+// the client adds, or one of the Is* helpers below.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Parsed error body, nil when OneFuse did not answer with JSON
+	Details *APIErrorDetails
+	// Raw response body
+	Body string
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("onefuse.apiClient: %s %s returned %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if detail := e.Message(); detail != "" {
+		message = fmt.Sprintf("%s: %s", message, detail)
+	}
+	if jobTrackingID := e.JobTrackingID(); jobTrackingID != "" {
+		message = fmt.Sprintf("%s (job tracking ID %s)", message, jobTrackingID)
+	}
+	return message
+}
+
+// Returns the messages OneFuse gave for the failure, or the raw body when it could not be parsed.
+func (e *APIError) Message() string {
+	if e.Details == nil {
+		return strings.TrimSpace(e.Body)
+	}
+
+	var messages []string
+	if e.Details.Detail != "" {
+		messages = append(messages, e.Details.Detail)
+	}
+	for _, detail := range e.Details.Errors {
+		if detail.Message != "" {
+			messages = append(messages, detail.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return strings.TrimSpace(e.Body)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Returns the tracking ID of the job the failed request belonged to, if OneFuse reported one.
+func (e *APIError) JobTrackingID() string {
+	if e.Details == nil {
+		return ""
+	}
+	return e.Details.JobTrackingID
+}
+
+func newAPIError(res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       string(body),
+	}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.String()
+	}
+
+	details := APIErrorDetails{}
+	if err := json.Unmarshal(body, &details); err == nil {
+		apiErr.Details = &details
+	}
+
+	return apiErr
+}
+
+// Returns the status code of the API error wrapped in err, or 0 when err is not an API error.
+func ErrorStatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// Reports whether err is OneFuse answering that the object does not exist.
+func IsNotFound(err error) bool {
+	return ErrorStatusCode(err) == http.StatusNotFound
+}
+
+// Reports whether err is OneFuse refusing the request because it conflicts with an existing object.
+func IsConflict(err error) bool {
+	return ErrorStatusCode(err) == http.StatusConflict
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func newErrorTestServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	server := newErrorTestServer(http.StatusNotFound, `{"detail": "Not found."}`)
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+	_, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1)
+	if !IsNotFound(err) {
+		t.Fatalf("Expected a not found error but got '%v'", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an *APIError but got %T", err)
+	}
+	if apiErr.Method != "GET" || !strings.HasSuffix(apiErr.URL, "/microsoftADPolicies/1/") {
+		t.Errorf("Bad request on error; got %s %s", apiErr.Method, apiErr.URL)
+	}
+	if apiErr.Message() != "Not found." {
+		t.Errorf("Bad message; expected 'Not found.' but got '%s'", apiErr.Message())
+	}
+}
+
+func TestAPIErrorDetails(t *testing.T) {
+	tables := []struct {
+		status   int
+		body     string
+		message  string
+		notFound bool
+	}{
+		{http.StatusBadRequest, `{"code": 400, "errors": [{"message": "name is required"}, {"message": "policy is required"}], "jobTrackingId": "abc-123"}`,
+			"name is required; policy is required (job tracking ID abc-123)", false},
+		{http.StatusInternalServerError, "Server Error", "returned 500 Internal Server Error: Server Error", false},
+		{http.StatusConflict, `{"detail": "Name already exists."}`, "Name already exists.", false},
+	}
+
+	for _, table := range tables {
+		server := newErrorTestServer(table.status, table.body)
+
+		config := configForTestServer(t, server)
+		_, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1)
+		if err == nil || !strings.Contains(err.Error(), table.message) {
+			t.Errorf("Expected error containing '%s' but got '%v'", table.message, err)
+		}
+		if ErrorStatusCode(err) != table.status {
+			t.Errorf("Bad status code; expected %d but got %d", table.status, ErrorStatusCode(err))
+		}
+		if IsNotFound(err) != table.notFound {
+			t.Errorf("Bad IsNotFound for %d response", table.status)
+		}
+
+		server.Close()
+	}
+}
+
+func TestAPIErrorHelpersIgnoreOtherErrors(t *testing.T) {
+	err := errors.New("onefuse.apiClient: Failed to do request")
+	if IsNotFound(err) || IsConflict(err) || ErrorStatusCode(err) != 0 {
+		t.Errorf("Expected helpers to ignore errors that did not come from the API")
+	}
+	if IsNotFound(nil) {
+		t.Errorf("Expected a nil error not to be not found")
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DefaultMaxRetries = 3
+const DefaultRetryWaitMin = time.Second
+const DefaultRetryWaitMax = 30 * time.Second
+
+// Sends the request, retrying connection failures and the responses a load balancer gives
+// while OneFuse is briefly unavailable.
+//
+// Idempotent requests are retried on any connection error and on 429, 502, 503 and 504.
+// POSTs are retried on the same failures, but when the failure does not show the request was
+// refused, such as a reset connection or a 502, OneFuse is first asked whether it already
+// created the object. If it did, that is returned instead of sending the request again.
+// Deployments cannot be told apart from ones started earlier, so like other requests they are
+// only retried when the connection could not be opened, or OneFuse answered 429 or 503.
+func doRequestWithRetry(config *Config, req *http.Request, idempotent bool) (*http.Response, error) {
+	ctx := config.context()
+	req = req.WithContext(ctx)
+
+	maxRetries := config.maxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := sendRequest(config, req)
+		resendable := idempotent || req.Method == http.MethodPost
+		if attempt >= maxRetries || ctx.Err() != nil || !shouldRetry(res, err, resendable) {
+			return res, err
+		}
+
+		if !idempotent && !shouldRetry(res, err, false) {
+			created, lookupErr := findCreated(config, req)
+			if lookupErr != nil {
+				log.Printf("onefuse.apiClient: Not retrying %s %s, cannot tell whether it was accepted: %s", req.Method, req.URL, lookupErr)
+				return res, err
+			}
+			if created != nil {
+				log.Printf("onefuse.apiClient: %s %s was accepted before it failed, not sending it again", req.Method, req.URL)
+				if res != nil {
+					io.Copy(ioutil.Discard, res.Body)
+					res.Body.Close()
+				}
+				return created, nil
+			}
+		}
+
+		retry, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return res, err
+		}
+
+		wait := backoffWithJitter(config.retryWaitMin, config.retryWaitMax, attempt)
+		if res != nil {
+			if after, ok := retryAfter(res); ok {
+				wait = after
+			}
+			log.Printf("onefuse.apiClient: %s %s returned %d, retrying in %s", req.Method, req.URL, res.StatusCode, wait)
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		} else {
+			log.Printf("onefuse.apiClient: %s %s failed with '%s', retrying in %s", req.Method, req.URL, err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.WithMessage(ctx.Err(), "onefuse.apiClient: Interrupted while retrying request")
+		case <-time.After(wait):
+		}
+
+		req = retry
+	}
+}
+
+func shouldRetry(res *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		if isTLSError(err) {
+			return false
+		}
+		return idempotent || isDialError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Looks up what an earlier attempt of a POST created: the object with the name and workspace
+// in the request body. Returns the response to reading it back, which has the body the POST
+// would have answered with, or nil when nothing was created and the POST can be sent again.
+func findCreated(config *Config, req *http.Request) (*http.Response, error) {
+	if req.GetBody == nil {
+		return nil, errors.New("onefuse.apiClient: Request body cannot be read again")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	created := struct {
+		Name         string `json:"name"`
+		WorkspaceURL string `json:"workspace"`
+		Policy       string `json:"policy"`
+	}{}
+	if err = json.NewDecoder(body).Decode(&created); err != nil {
+		return nil, errors.WithMessage(err, "onefuse.apiClient: Failed to read request body")
+	}
+	// Deployments of a policy may share a name, so one found could have been there before
+	if created.Policy != "" {
+		return nil, errors.New("onefuse.apiClient: Request starts a deployment")
+	}
+	if created.Name == "" {
+		return nil, errors.New("onefuse.apiClient: Request does not create a named object")
+	}
+
+	filter := "name.exact:" + created.Name
+	if created.WorkspaceURL != "" {
+		workspaceID, err := LinkRef{Href: created.WorkspaceURL}.ID()
+		if err != nil {
+			return nil, err
+		}
+		filter += fmt.Sprintf(";workspace.id:%d", workspaceID)
+	}
+
+	collection := *req.URL
+	collection.RawQuery = ""
+	return findCreatedByFilter(config, collection.String(), filter)
+}
+
+// Finds the one item of the collection matching filter and returns the response to getting it.
+func findCreatedByFilter(config *Config, collection string, filter string) (*http.Response, error) {
+	found := struct {
+		Embedded map[string][]struct {
+			Links struct {
+				Self LinkRef `json:"self"`
+			} `json:"_links"`
+		} `json:"_embedded"`
+	}{}
+	if err := doGet(config, fmt.Sprintf("%s?filter=%s", collection, url.QueryEscape(filter)), &found); err != nil {
+		return nil, err
+	}
+
+	var matches []LinkRef
+	for _, items := range found.Embedded {
+		for _, item := range items {
+			matches = append(matches, item.Links.Self)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	if len(matches) > 1 {
+		return nil, errors.New(fmt.Sprintf("onefuse.apiClient: Found %d matches for '%s', expected one", len(matches), filter))
+	}
+
+	req, err := http.NewRequest("GET", urlFromHref(config, matches[0].Href), nil)
+	if err != nil {
+		return nil, err
+	}
+	setHeaders(req, config)
+	return doRequest(config, req)
+}
+
+// Reports whether the error happened while opening the connection, before any of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// Reports whether the error is a certificate or handshake problem, which retrying will not fix.
+func isTLSError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var certificateInvalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var recordHeader tls.RecordHeaderError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &certificateInvalid) ||
+		errors.As(err, &hostname) || errors.As(err, &recordHeader) {
+		return true
+	}
+
+	// Alerts sent by the server, for example when it requires a client certificate
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "remote error"
+}
+
+// Parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// Returns min doubled for every attempt and capped at max, with up to half of it randomised
+// so parallel resources do not hit OneFuse in lockstep.
+func backoffWithJitter(min time.Duration, max time.Duration, attempt int) time.Duration {
+	if min <= 0 {
+		min = DefaultRetryWaitMin
+	}
+	if max < min {
+		max = min
+	}
+
+	wait := min
+	for i := 0; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,288 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudboltsoftware/terraform-provider-onefuse/onefuse/onefusetest"
+)
+
+// Returns a server that answers the first failures requests with status and then succeeds.
+func newFlakyTestServer(failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "value": "rendered"}`))
+	}))
+	return server, &requests
+}
+
+func configForRetryTest(t *testing.T, server *httptest.Server) Config {
+	config := configForTestServer(t, server)
+	config.retryWaitMin = time.Millisecond
+	config.retryWaitMax = time.Millisecond
+	return config
+}
+
+func TestRetryGetOnTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		server, requests := newFlakyTestServer(2, status, "")
+
+		config := configForRetryTest(t, server)
+		if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+			t.Errorf("Error getting policy after %d responses: '%s'", status, err)
+		}
+		if *requests != 3 {
+			t.Errorf("Expected 3 requests after two %d responses but got %d", status, *requests)
+		}
+
+		server.Close()
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	server, requests := newFlakyTestServer(100, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	config := configForRetryTest(t, server)
+	config.maxRetries = 2
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err == nil {
+		t.Errorf("Expected an error after retries were exhausted")
+	}
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests but got %d", *requests)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	server, _ := newFlakyTestServer(1, http.StatusServiceUnavailable, "1")
+	defer server.Close()
+
+	config := configForRetryTest(t, server)
+
+	start := time.Now()
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy: '%s'", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected to wait at least 1s as asked by Retry-After but waited %s", elapsed)
+	}
+}
+
+func TestRetryPostOnlyWhenNotAccepted(t *testing.T) {
+	tables := []struct {
+		status   int
+		requests int32
+	}{
+		// OneFuse refused the request, so no job was started
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusTooManyRequests, 2},
+		// OneFuse may have created the policy before the gateway gave up, and without a name
+		// there is no way to look it up
+		{http.StatusBadGateway, 1},
+		{http.StatusGatewayTimeout, 1},
+	}
+
+	for _, table := range tables {
+		server, requests := newFlakyTestServer(1, table.status, "")
+
+		config := configForRetryTest(t, server)
+		config.NewOneFuseApiClient().CreateMicrosoftADPolicy(&MicrosoftADPolicy{WorkspaceURL: "/api/v3/onefuse/workspaces/1/"})
+		if *requests != table.requests {
+			t.Errorf("Expected %d POST requests after a %d response but got %d", table.requests, table.status, *requests)
+		}
+
+		server.Close()
+	}
+}
+
+func TestRetryPutAndDeleteOnBadGateway(t *testing.T) {
+	server, requests := newFlakyTestServer(1, http.StatusBadGateway, "")
+	defer server.Close()
+
+	config := configForRetryTest(t, server)
+	apiClient := config.NewOneFuseApiClient()
+
+	if _, err := apiClient.UpdateMicrosoftADPolicy(1, &MicrosoftADPolicy{Name: "policy", WorkspaceURL: "/api/v3/onefuse/workspaces/1/"}); err != nil {
+		t.Errorf("Error updating policy after a 502 response: '%s'", err)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected 2 PUT requests but got %d", *requests)
+	}
+
+	atomic.StoreInt32(requests, 0)
+	if err := apiClient.DeleteMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error deleting policy after a 502 response: '%s'", err)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected 2 DELETE requests but got %d", *requests)
+	}
+}
+
+// Counts the requests the fake server received for method and path.
+func countRequests(server *onefusetest.Server, method string, path string) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if request == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRetryPostDoesNotResendDeployment(t *testing.T) {
+	server := onefusetest.NewServer()
+	defer server.Close()
+	server.DropResponses(http.MethodPost, DNSReservationResourceType, 1)
+
+	config := fakeServerConfig(server)
+	config.retryWaitMin = time.Millisecond
+	config.retryWaitMax = time.Millisecond
+
+	if _, err := config.NewOneFuseApiClient().CreateDNSReservation(&DNSReservation{Name: "host", PolicyID: 1}); err == nil {
+		t.Errorf("Expected an error creating a DNS Reservation after the response was lost")
+	}
+	if server.Get(DNSReservationResourceType, 2) != nil {
+		t.Errorf("Expected only one DNS Reservation to be created")
+	}
+	if posts := countRequests(server, http.MethodPost, onefusetest.ApiPrefix+DNSReservationResourceType+"/"); posts != 1 {
+		t.Errorf("Expected 1 POST request but got %d", posts)
+	}
+}
+
+func TestRetryPostFindsCreatedObject(t *testing.T) {
+	server := onefusetest.NewServer()
+	defer server.Close()
+	server.Add(DNSPolicyResourceType, map[string]interface{}{"name": "tfRetryDNSPolicy", "workspace": onefusetest.Href(WorkspaceResourceType, 1)})
+	server.DropResponses(http.MethodPost, DNSPolicyResourceType, 1)
+
+	config := fakeServerConfig(server)
+	config.retryWaitMin = time.Millisecond
+	config.retryWaitMax = time.Millisecond
+
+	// A policy of the same name in another workspace is not mistaken for the new one
+	workspace, err := config.NewOneFuseApiClient().CreateWorkspace(&Workspace{Name: "tfRetryWorkspace"})
+	if err != nil {
+		t.Fatalf("Error creating Workspace: '%s'", err)
+	}
+
+	policy, err := config.NewOneFuseApiClient().CreateDNSPolicy(&DNSPolicy{Name: "tfRetryDNSPolicy", EndpointID: 1, WorkspaceURL: strconv.Itoa(workspace.ID)})
+	if err != nil {
+		t.Fatalf("Error creating DNS Policy after the response was lost: '%s'", err)
+	}
+	if policy.ID != 2 || server.Get(DNSPolicyResourceType, 3) != nil {
+		t.Errorf("Expected only DNS Policy 2 to be created but got %d", policy.ID)
+	}
+	if posts := countRequests(server, http.MethodPost, onefusetest.ApiPrefix+DNSPolicyResourceType+"/"); posts != 1 {
+		t.Errorf("Expected 1 POST request but got %d", posts)
+	}
+}
+
+func TestRetryPostResendsWhenNotCreated(t *testing.T) {
+	server := onefusetest.NewServer()
+	defer server.Close()
+	server.DropRequests(http.MethodPost, DNSPolicyResourceType, 1)
+
+	config := fakeServerConfig(server)
+	config.retryWaitMin = time.Millisecond
+	config.retryWaitMax = time.Millisecond
+
+	if _, err := config.NewOneFuseApiClient().CreateDNSPolicy(&DNSPolicy{Name: "tfRetryDNSPolicy", EndpointID: 1}); err != nil {
+		t.Errorf("Error creating DNS Policy after the request was lost: '%s'", err)
+	}
+	if posts := countRequests(server, http.MethodPost, onefusetest.ApiPrefix+DNSPolicyResourceType+"/"); posts != 2 {
+		t.Errorf("Expected 2 POST requests but got %d", posts)
+	}
+	if server.Get(DNSPolicyResourceType, 1) == nil || server.Get(DNSPolicyResourceType, 2) != nil {
+		t.Errorf("Expected exactly one DNS Policy to be created")
+	}
+}
+
+func TestRetryRenderTemplateAsIdempotent(t *testing.T) {
+	server, requests := newFlakyTestServer(1, http.StatusBadGateway, "")
+	defer server.Close()
+
+	config := configForRetryTest(t, server)
+	renderedTemplate, err := config.NewOneFuseApiClient().RenderTemplate("{{value}}", nil)
+	if err != nil {
+		t.Errorf("Error rendering template: '%s'", err)
+		return
+	}
+	if renderedTemplate.Value != "rendered" || *requests != 2 {
+		t.Errorf("Expected the template to be rendered on the second request but got '%s' after %d requests", renderedTemplate.Value, *requests)
+	}
+}
+
+func TestRetryConnectionRefused(t *testing.T) {
+	// Reserve a port and close it so connections are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error reserving port: '%s'", err)
+	}
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	config := NewConfig("http", "127.0.0.1", port, "admin", "admin", true)
+	config.retryWaitMin = 200 * time.Millisecond
+	config.retryWaitMax = 200 * time.Millisecond
+
+	// Start the server on the reserved port while the client is waiting to retry
+	var requests int32
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id": 1}`))
+	})}
+	time.AfterFunc(50*time.Millisecond, func() {
+		if listener, err := net.Listen("tcp", "127.0.0.1:"+port); err == nil {
+			server.Serve(listener)
+		}
+	})
+	defer server.Close()
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy after connection was refused: '%s'", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request to reach the server but got %d", requests)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	tables := []struct {
+		value string
+		ok    bool
+		wait  time.Duration
+	}{
+		{"", false, 0},
+		{"5", true, 5 * time.Second},
+		{"soon", false, 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), true, 0},
+	}
+
+	for _, table := range tables {
+		res := &http.Response{Header: http.Header{}}
+		res.Header.Set("Retry-After", table.value)
+		wait, ok := retryAfter(res)
+		if ok != table.ok || wait != table.wait {
+			t.Errorf("Bad Retry-After for '%s'; expected %s, %t but got %s, %t", table.value, table.wait, table.ok, wait, ok)
+		}
+	}
+}
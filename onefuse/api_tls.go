@@ -0,0 +1,60 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/hashicorp/terraform/helper/pathorcontents"
+	"github.com/pkg/errors"
+)
+
+// Builds the TLS settings for connections to OneFuse. The CA bundle, client certificate and
+// client key may each be given as a path to a PEM file or as inline PEM content.
+func buildTLSConfig(verifySSL bool, caCert string, clientCert string, clientKey string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: !verifySSL}
+
+	if caCert != "" {
+		caPEM, _, err := pathorcontents.Read(caCert)
+		if err != nil {
+			return nil, errors.WithMessage(err, "onefuse.buildTLSConfig: Failed to read ca_cert")
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, errors.New("onefuse.buildTLSConfig: ca_cert does not contain any PEM encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, errors.New("onefuse.buildTLSConfig: client_cert and client_key must be set together")
+		}
+
+		certPEM, _, err := pathorcontents.Read(clientCert)
+		if err != nil {
+			return nil, errors.WithMessage(err, "onefuse.buildTLSConfig: Failed to read client_cert")
+		}
+		keyPEM, _, err := pathorcontents.Read(clientKey)
+		if err != nil {
+			return nil, errors.WithMessage(err, "onefuse.buildTLSConfig: Failed to read client_key")
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return nil, errors.WithMessage(err, "onefuse.buildTLSConfig: Failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+func generateTestCertificate(t *testing.T, commonName string, parent *testCertificate, isCA bool) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: '%s'", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Error creating certificate: '%s'", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: '%s'", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshalling key: '%s'", err)
+	}
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+}
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestTLSCustomCABundleInline(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err == nil {
+		t.Errorf("Expected an error verifying a certificate from an unknown CA")
+	}
+
+	tlsConfig, err := buildTLSConfig(true, serverCAPEM(server), "", "")
+	if err != nil {
+		t.Fatalf("Error building TLS config: '%s'", err)
+	}
+	config.tlsConfig = tlsConfig
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy with custom CA bundle: '%s'", err)
+	}
+}
+
+func TestTLSCustomCABundleFile(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "onefuse-tls")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: '%s'", err)
+	}
+	defer os.RemoveAll(dir)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := ioutil.WriteFile(caFile, []byte(serverCAPEM(server)), 0600); err != nil {
+		t.Fatalf("Error writing CA bundle: '%s'", err)
+	}
+
+	tlsConfig, err := buildTLSConfig(true, caFile, "", "")
+	if err != nil {
+		t.Fatalf("Error building TLS config: '%s'", err)
+	}
+
+	config := configForTestServer(t, server)
+	config.tlsConfig = tlsConfig
+
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy with CA bundle file: '%s'", err)
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	clientCA := generateTestCertificate(t, "client-ca", nil, true)
+	clientCert := generateTestCertificate(t, "terraform", clientCA, false)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+
+	tlsConfig, err := buildTLSConfig(true, serverCAPEM(server), "", "")
+	if err != nil {
+		t.Fatalf("Error building TLS config: '%s'", err)
+	}
+	config.tlsConfig = tlsConfig
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err == nil {
+		t.Errorf("Expected an error connecting without a client certificate")
+	}
+
+	tlsConfig, err = buildTLSConfig(true, serverCAPEM(server), clientCert.certPEM, clientCert.keyPEM)
+	if err != nil {
+		t.Fatalf("Error building TLS config: '%s'", err)
+	}
+	config.tlsConfig = tlsConfig
+	if _, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(1); err != nil {
+		t.Errorf("Error getting policy with client certificate: '%s'", err)
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	tables := []struct {
+		name       string
+		caCert     string
+		clientCert string
+		clientKey  string
+	}{
+		{"bad CA bundle", "not a certificate", "", ""},
+		{"cert without key", "", "cert", ""},
+		{"key without cert", "", "", "key"},
+		{"bad key pair", "", "cert", "key"},
+	}
+
+	for _, table := range tables {
+		if _, err := buildTLSConfig(true, table.caCert, table.clientCert, table.clientKey); err == nil {
+			t.Errorf("Missing error building TLS config for %s", table.name)
+		}
+	}
+}
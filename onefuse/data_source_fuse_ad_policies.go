@@ -18,14 +18,23 @@ func dataSourceADPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceADPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceADPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	adPolicy, err := apiClient.GetADPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "AD Policy")
+	if err != nil {
+		return err
+	}
+
+	var adPolicy *ADPolicy
+	if id != 0 {
+		adPolicy, err = apiClient.GetADPolicy(id)
+	} else {
+		adPolicy, err = apiClient.GetADPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading AD Policy: %s", err)
@@ -18,14 +18,23 @@ func dataSourceAnsibleTowerPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceAnsibleTowerPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceAnsibleTowerPolicyRead(d *schema.ResourceData, meta interface{})
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	ansibleTowerPolicy, err := apiClient.GetAnsibleTowerPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "Ansible Tower Policy")
+	if err != nil {
+		return err
+	}
+
+	var ansibleTowerPolicy *AnsibleTowerPolicy
+	if id != 0 {
+		ansibleTowerPolicy, err = apiClient.GetAnsibleTowerPolicy(id)
+	} else {
+		ansibleTowerPolicy, err = apiClient.GetAnsibleTowerPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading Ansible Tower Policy: %s", err)
@@ -18,14 +18,23 @@ func dataSourceDNSPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceDNSPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceDNSPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	dnsPolicy, err := apiClient.GetDNSPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "DNS Policy")
+	if err != nil {
+		return err
+	}
+
+	var dnsPolicy *DNSPolicy
+	if id != 0 {
+		dnsPolicy, err = apiClient.GetDNSPolicy(id)
+	} else {
+		dnsPolicy, err = apiClient.GetDNSPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading DNS Policy: %s", err)
@@ -0,0 +1,62 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Returns the data source looking up endpoints of endpointType by name.
+func dataSourceEndpoint(endpointType string, label string) *schema.Resource {
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return dataSourceEndpointRead(d, meta, endpointType, label)
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEndpointRead(d *schema.ResourceData, meta interface{}, endpointType string, label string) error {
+	log.Println("onefuse.dataSourceEndpointRead")
+
+	config := meta.(Config)
+	apiClient := config.NewOneFuseApiClient()
+
+	endpoint, err := apiClient.GetEndpointByName(d.Get("name").(string), endpointType)
+
+	if err != nil {
+		return fmt.Errorf("Error loading %s: %s", label, err)
+	}
+
+	d.SetId(strconv.Itoa(endpoint.ID))
+	d.Set("name", endpoint.Name)
+	d.Set("description", endpoint.Description)
+	d.Set("host", endpoint.Host)
+
+	return nil
+}
@@ -18,14 +18,23 @@ func dataSourceIPAMPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceIPAMPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceIPAMPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	ipamPolicy, err := apiClient.GetIPAMPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "IPAM Policy")
+	if err != nil {
+		return err
+	}
+
+	var ipamPolicy *IPAMPolicy
+	if id != 0 {
+		ipamPolicy, err = apiClient.GetIPAMPolicy(id)
+	} else {
+		ipamPolicy, err = apiClient.GetIPAMPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading IPAM Policy: %s", err)
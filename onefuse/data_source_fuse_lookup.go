@@ -0,0 +1,33 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+// Returns the ID a data source looks its object up by, or 0 when it looks it up by name.
+// Exactly one of "id" and "name" may be set.
+func dataSourceLookupID(d *schema.ResourceData, label string) (int, error) {
+	id := d.Get("id").(string)
+	if id == "" {
+		if d.Get("name").(string) == "" {
+			return 0, errors.New(fmt.Sprintf("Error loading %s: one of id or name must be set", label))
+		}
+		return 0, nil
+	}
+
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, errors.WithMessage(err, fmt.Sprintf("Error loading %s: id '%s' is not a number", label, id))
+	}
+	return intID, nil
+}
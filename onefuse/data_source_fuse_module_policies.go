@@ -18,14 +18,23 @@ func dataSourceModulePolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceModulePolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceModulePolicyRead(d *schema.ResourceData, meta interface{}) error
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	ModulePolicy, err := apiClient.GetModulePolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "Module Policy")
+	if err != nil {
+		return err
+	}
+
+	var ModulePolicy *ModulePolicy
+	if id != 0 {
+		ModulePolicy, err = apiClient.GetModulePolicy(id)
+	} else {
+		ModulePolicy, err = apiClient.GetModulePolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading Module Policy: %s", err)
@@ -18,14 +18,23 @@ func dataSourceNamingPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNamingPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceNamingPolicyRead(d *schema.ResourceData, meta interface{}) error
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	namingPolicy, err := apiClient.GetNamingPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "Naming Policy")
+	if err != nil {
+		return err
+	}
+
+	var namingPolicy *NamingPolicy
+	if id != 0 {
+		namingPolicy, err = apiClient.GetNamingPolicy(id)
+	} else {
+		namingPolicy, err = apiClient.GetNamingPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading Naming Policy: %s", err)
@@ -0,0 +1,177 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// Requires a policy of each type named "myPolicy", except for the Naming Policy.
+var policyDataSources = []struct {
+	dataSource string
+	name       string
+}{
+	{"onefuse_ipam_policy", getEnv("CB_ONEFUSE_CFG_IPAM_POLICY_NAME", "myPolicy")},
+	{"onefuse_naming_policy", getEnv("CB_ONEFUSE_CFG_NAMING_POLICY_NAME", "myNamingPolicy")},
+	{"onefuse_ad_policy", getEnv("CB_ONEFUSE_CFG_MICROSOFT_AD_POLICY_NAME", "myPolicy")},
+	{"onefuse_dns_policy", getEnv("CB_ONEFUSE_CFG_DNS_POLICY_NAME", "myPolicy")},
+	{"onefuse_scripting_policy", getEnv("CB_ONEFUSE_CFG_SCRIPTING_POLICY_NAME", "myPolicy")},
+	{"onefuse_ansible_tower_policy", getEnv("CB_ONEFUSE_CFG_ANSIBLE_TOWER_POLICY_NAME", "myPolicy")},
+	{"onefuse_servicenow_cmdb_policy", getEnv("CB_ONEFUSE_CFG_SERVICENOW_CMDB_POLICY_NAME", "myPolicy")},
+	{"onefuse_module_policy", getEnv("CB_ONEFUSE_CFG_MODULE_POLICY_NAME", "myPolicy")},
+	{"onefuse_vra_policy", getEnv("CB_ONEFUSE_CFG_VRA_POLICY_NAME", "myPolicy")},
+}
+
+func TestAccDataSourcePolicies(t *testing.T) {
+	for _, table := range policyDataSources {
+		dataSourceName := fmt.Sprintf("data.%s.policy", table.dataSource)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccPolicyDataSourceConfig(table.dataSource, table.name),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+						resource.TestCheckResourceAttr(dataSourceName, "name", table.name),
+						resource.TestCheckResourceAttrPair(dataSourceName+"_by_id", "id", dataSourceName, "id"),
+						resource.TestCheckResourceAttrPair(dataSourceName+"_by_id", "name", dataSourceName, "name"),
+						resource.TestCheckResourceAttrPair(dataSourceName+"_by_id", "description", dataSourceName, "description"),
+					),
+				},
+			},
+		})
+	}
+}
+
+func TestAccDataSourcePolicyRequiresIDOrName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+data "onefuse_dns_policy" "policy" {
+}
+`,
+				ExpectError: regexp.MustCompile("one of id or name must be set"),
+			},
+		},
+	})
+}
+
+// Policies with the same name in different workspaces are told apart by the workspace.
+func TestAccDataSourcePolicyWorkspace(t *testing.T) {
+	dnsEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_DNS_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_dns_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetDNSPolicy(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_workspace", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetWorkspace(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyDataSourceWorkspaceConfig(dnsEndpointID),
+			},
+			{
+				// The data sources are read once the policies exist.
+				Config: testAccPolicyDataSourceWorkspaceConfig(dnsEndpointID) + `
+data "onefuse_dns_policy" "team01" {
+  name      = "tfAccWorkspacePolicy"
+  workspace = onefuse_workspace.team01.name
+}
+
+data "onefuse_dns_policy" "team02" {
+  name      = "tfAccWorkspacePolicy"
+  workspace = onefuse_workspace.team02.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.onefuse_dns_policy.team01", "id", "onefuse_dns_policy.team01", "id"),
+					resource.TestCheckResourceAttrPair("data.onefuse_dns_policy.team02", "id", "onefuse_dns_policy.team02", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyDataSourceWorkspaceConfig(dnsEndpointID int) string {
+	config := testAccProviderConfig()
+	for _, team := range []string{"team01", "team02"} {
+		config += fmt.Sprintf(`
+resource "onefuse_workspace" %[1]q {
+  name = "tfAccWorkspace_%[1]s"
+}
+
+resource "onefuse_dns_policy" %[1]q {
+  name            = "tfAccWorkspacePolicy"
+  description     = "Created by the acceptance tests for %[1]s"
+  dns_endpoint_id = %[2]d
+  zones           = ["example.com"]
+  workspace_url   = onefuse_workspace.%[1]s.id
+
+  record {
+    type  = "a"
+    name  = "{{ hostname }}"
+    value = "{{ ipAddress }}"
+  }
+}
+`, team, dnsEndpointID)
+	}
+	return config
+}
+
+// Requires a Static Property Set named "sps_fake"
+func TestAccDataSourceStaticPropertySet(t *testing.T) {
+	dataSourceName := "data.onefuse_static_property_set.sps"
+	name := getEnv("CB_ONEFUSE_CFG_STATIC_PROPERTY_SET_NAME", "sps_fake")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "onefuse_static_property_set" "sps" {
+  name = %q
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+					resource.TestCheckResourceAttrSet(dataSourceName, "raw"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyDataSourceConfig(dataSource string, name string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+data %[1]q "policy" {
+  name = %[2]q
+}
+
+data %[1]q "policy_by_id" {
+  id = data.%[1]s.policy.id
+}
+`, dataSource, name)
+}
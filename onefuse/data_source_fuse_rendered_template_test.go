@@ -8,6 +8,8 @@ package onefuse
 
 import (
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestRenderedTemplate(t *testing.T) {
@@ -31,3 +33,25 @@ func TestRenderedTemplate(t *testing.T) {
 		return
 	}
 }
+
+func TestAccDataSourceRenderedTemplate(t *testing.T) {
+	dataSourceName := "data.onefuse_rendered_template.template"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+data "onefuse_rendered_template" "template" {
+  template = "template {{templatedValue}}"
+  template_properties = {
+    templatedValue = "this is the value"
+  }
+}
+`,
+				Check: resource.TestCheckResourceAttr(dataSourceName, "value", "template this is the value"),
+			},
+		},
+	})
+}
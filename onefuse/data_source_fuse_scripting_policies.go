@@ -18,14 +18,23 @@ func dataSourceScriptingPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceScriptingPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceScriptingPolicyRead(d *schema.ResourceData, meta interface{}) err
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	scriptingPolicy, err := apiClient.GetScriptingPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "Scripting Policy")
+	if err != nil {
+		return err
+	}
+
+	var scriptingPolicy *ScriptingPolicy
+	if id != 0 {
+		scriptingPolicy, err = apiClient.GetScriptingPolicy(id)
+	} else {
+		scriptingPolicy, err = apiClient.GetScriptingPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading Scripting Policy: %s", err)
@@ -18,14 +18,23 @@ func dataSourceServicenowCMDBPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceServicenowCMDBPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceServicenowCMDBPolicyRead(d *schema.ResourceData, meta interface{}
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	servicenowCMDBPolicy, err := apiClient.GetServicenowCMDBPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "ServicenowCMDB Policy")
+	if err != nil {
+		return err
+	}
+
+	var servicenowCMDBPolicy *ServicenowCMDBPolicy
+	if id != 0 {
+		servicenowCMDBPolicy, err = apiClient.GetServicenowCMDBPolicy(id)
+	} else {
+		servicenowCMDBPolicy, err = apiClient.GetServicenowCMDBPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading ServicenowCMDB Policy: %s", err)
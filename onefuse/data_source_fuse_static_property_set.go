@@ -38,6 +38,11 @@ func dataSourceStaticPropertySet() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"properties_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The properties exactly as OneFuse returns them, for jsondecode()",
+			},
 		},
 	}
 }
@@ -56,8 +61,10 @@ func dataSourceStaticPropertySetRead(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(strconv.Itoa(staticPropertySet.ID))
 	d.Set("name", staticPropertySet.Name)
+	d.Set("description", staticPropertySet.Description)
 	d.Set("properties", staticPropertySet.Properties)
 	d.Set("raw", staticPropertySet.Raw)
+	d.Set("properties_json", string(staticPropertySet.PropertiesJSON))
 
 	return nil
 }
@@ -18,14 +18,23 @@ func dataSourceVraPolicy() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceVraPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+			},
+			"workspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "URL, ID or name of the workspace the name is looked up in",
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -40,7 +49,17 @@ func dataSourceVraPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(Config)
 	apiClient := config.NewOneFuseApiClient()
 
-	vraPolicy, err := apiClient.GetVraPolicyByName(d.Get("name").(string))
+	id, err := dataSourceLookupID(d, "vRA Policy")
+	if err != nil {
+		return err
+	}
+
+	var vraPolicy *VraPolicy
+	if id != 0 {
+		vraPolicy, err = apiClient.GetVraPolicy(id)
+	} else {
+		vraPolicy, err = apiClient.GetVraPolicyByName(d.Get("name").(string), d.Get("workspace").(string))
+	}
 
 	if err != nil {
 		return fmt.Errorf("Error loading vRA Policy: %s", err)
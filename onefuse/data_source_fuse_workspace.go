@@ -0,0 +1,54 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceWorkspace() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceWorkspaceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceRead(d *schema.ResourceData, meta interface{}) error {
+	log.Println("onefuse.dataSourceWorkspaceRead")
+
+	config := meta.(Config)
+	apiClient := config.NewOneFuseApiClient()
+
+	workspace, err := apiClient.GetWorkspaceByName(d.Get("name").(string))
+
+	if err != nil {
+		return fmt.Errorf("Error loading Workspace: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(workspace.ID))
+	d.Set("name", workspace.Name)
+	d.Set("description", workspace.Description)
+
+	return nil
+}
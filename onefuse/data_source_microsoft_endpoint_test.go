@@ -7,7 +7,10 @@
 package onefuse
 
 import (
+	"fmt"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
 )
 
 // Requires a Microsoft Endpoint named "myMicrosoftEndpoint"
@@ -32,3 +35,26 @@ func TestGetMicrosoftEndpointByName(t *testing.T) {
 	}
 
 }
+
+func TestAccDataSourceMicrosoftEndpoint(t *testing.T) {
+	dataSourceName := "data.onefuse_microsoft_endpoint.endpoint"
+	name := getEnv("CB_ONEFUSE_CFG_MICROSOFT_ENDPOINT_NAME", "myMicrosoftEndpoint")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "onefuse_microsoft_endpoint" "endpoint" {
+  name = %q
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cloudboltsoftware/terraform-provider-onefuse/onefuse/onefusetest"
+)
+
+// Shared fake OneFuse server, nil when the tests run against a live appliance.
+var fakeServer *onefusetest.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("CB_ONEFUSE_LIVE") == "" {
+		fakeServer = newFakeServer()
+	}
+
+	code := m.Run()
+
+	if fakeServer != nil {
+		fakeServer.Close()
+	}
+	os.Exit(code)
+}
+
+// Starts a fake OneFuse server with the objects the tests expect an appliance to have.
+// See README-testing.md.
+func newFakeServer() *onefusetest.Server {
+	server := onefusetest.NewServer()
+
+	server.Add("endpoints", map[string]interface{}{
+		"name": getEnv("CB_ONEFUSE_CFG_MICROSOFT_ENDPOINT_NAME", "myMicrosoftEndpoint"),
+		"type": "microsoft",
+		"host": "ad.example.com",
+		"port": 443,
+		"ssl":  true,
+	})
+	server.Add("endpoints", map[string]interface{}{
+		"name": getEnv("CB_ONEFUSE_CFG_INFOBLOX_ENDPOINT_NAME", "myInfobloxEndpoint"),
+		"type": "infoblox",
+		"host": "infoblox.example.com",
+		"port": 443,
+		"ssl":  true,
+	})
+	server.Add("namingPolicies", map[string]interface{}{"name": "myNamingPolicy"})
+	for _, resourceType := range []string{"ipamPolicies", "microsoftADPolicies", "dnsPolicies", "scriptingPolicies", "ansibleTowerPolicies",
+		"servicenowCMDBPolicies", "modulePolicies", "vraPolicies"} {
+		server.Add(resourceType, map[string]interface{}{"name": "myPolicy", "description": "Fake policy"})
+	}
+	server.Add("propertySets", map[string]interface{}{
+		"name":       "sps_fake",
+		"properties": map[string]interface{}{"property1": "value1"},
+	})
+
+	return server
+}
+
+func fakeServerConfig(server *onefusetest.Server) Config {
+	scheme, host, port := server.Address()
+	config := NewConfig(scheme, host, port, server.Username, server.Password, true)
+	config.pollInterval = time.Millisecond
+	config.pollMaxInterval = time.Millisecond
+	return config
+}
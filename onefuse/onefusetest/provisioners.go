@@ -0,0 +1,83 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefusetest
+
+import (
+	"fmt"
+)
+
+// Stand-ins for what OneFuse computes for each type of managed object.
+func defaultProvisioners() map[string]ProvisionFunc {
+	return map[string]ProvisionFunc{
+		"customNames": func(id int, object map[string]interface{}) {
+			object["name"] = fmt.Sprintf("fakehost%03d", id)
+			object["dnsSuffix"] = "example.com"
+		},
+		"microsoftADComputerAccounts": func(id int, object map[string]interface{}) {
+			setDefaults(object, map[string]interface{}{"finalOu": "OU=Computers,DC=example,DC=com"})
+		},
+		"dnsReservations": func(id int, object map[string]interface{}) {
+			records := []interface{}{}
+			if zones, ok := object["zones"].([]interface{}); ok {
+				for _, zone := range zones {
+					records = append(records, map[string]interface{}{
+						"type":  "a",
+						"name":  fmt.Sprintf("%v.%v", object["name"], zone),
+						"value": object["value"],
+					})
+				}
+			}
+			object["records"] = records
+		},
+		"ipamReservations": func(id int, object map[string]interface{}) {
+			setDefaults(object, map[string]interface{}{
+				"ipAddress":    fmt.Sprintf("10.0.0.%d", id%250+2),
+				"netmask":      "255.255.255.0",
+				"gateway":      "10.0.0.1",
+				"network":      "10.0.0.0",
+				"subnet":       "10.0.0.0/24",
+				"primaryDns":   "10.0.0.10",
+				"secondaryDns": "10.0.0.11",
+				"dnsSuffix":    "example.com",
+				"nicLabel":     "Network adapter 1",
+			})
+		},
+		"ansibleTowerDeployments": func(id int, object map[string]interface{}) {
+			object["inventoryName"] = "fake-inventory"
+			object["provisioningJobResults"] = []interface{}{
+				map[string]interface{}{"output": "ok", "status": "successful", "jobTemplateName": "fake-job-template"},
+			}
+		},
+		"scriptingDeployments": func(id int, object map[string]interface{}) {
+			object["provisioningDetails"] = map[string]interface{}{"status": "successful", "output": []interface{}{"ok"}}
+		},
+		"vraDeployments": func(id int, object map[string]interface{}) {
+			object["name"] = object["deploymentName"]
+			object["deploymentInfo"] = map[string]interface{}{"id": fmt.Sprintf("fake-vra-deployment-%d", id)}
+		},
+		"servicenowCMDBDeployments": func(id int, object map[string]interface{}) {
+			object["configurationItemsInfo"] = []interface{}{
+				map[string]interface{}{"ciClassName": "cmdb_ci_server", "ciName": fmt.Sprintf("fakeci%03d", id)},
+			}
+		},
+		"moduleManagedObjects": func(id int, object map[string]interface{}) {
+			object["name"] = fmt.Sprintf("fake-module-deployment-%d", id)
+			object["provisioningJobResults"] = []interface{}{
+				map[string]interface{}{"output": "ok", "status": "successful", "jobTemplateName": "fake-module"},
+			}
+		},
+	}
+}
+
+// Sets the fields the request left empty, keeping the ones that were asked for.
+func setDefaults(object map[string]interface{}, defaults map[string]interface{}) {
+	for field, value := range defaults {
+		if object[field] == nil || object[field] == "" {
+			object[field] = value
+		}
+	}
+}
@@ -0,0 +1,690 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package onefusetest provides an in-process stand-in for the OneFuse v3 API so the provider
+// can be tested without an appliance.
+//
+// The server keeps every object as a JSON map. Collections are served as HAL+JSON with
+// "filter=name.exact:" support, managed objects are created, updated and deleted through jobs that
+// go through the jobStatus lifecycle, and every job records its jobMetadata.
+package onefusetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ApiPrefix = "/api/v3/onefuse/"
+
+const JobSuccess = "Successful"
+const JobFailed = "Failed"
+const JobPending = "Pending"
+
+// Resource types whose POST, PUT and DELETE start a job instead of answering directly.
+var ManagedObjectTypes = []string{
+	"customNames",
+	"microsoftADComputerAccounts",
+	"dnsReservations",
+	"ipamReservations",
+	"ansibleTowerDeployments",
+	"scriptingDeployments",
+	"vraDeployments",
+	"servicenowCMDBDeployments",
+	"moduleManagedObjects",
+}
+
+// Fills in the fields OneFuse computes when it provisions a managed object.
+type ProvisionFunc func(id int, object map[string]interface{})
+
+type job struct {
+	id            int
+	jobType       string
+	pendingPolls  int
+	managedObject string
+	metadataID    int
+	failure       string
+}
+
+type Server struct {
+	*httptest.Server
+
+	// Credentials accepted as basic auth and exchanged for session tokens
+	Username string
+	Password string
+	// API token accepted as a bearer token, in addition to issued session tokens
+	Token string
+
+	// Number of times a job reports Pending before it finishes
+	PendingPolls int
+
+	mu            sync.Mutex
+	objects       map[string]map[int]map[string]interface{}
+	nextIDs       map[string]int
+	jobs          map[int]*job
+	nextJobID     int
+	sessionTokens map[string]bool
+	provisioners  map[string]ProvisionFunc
+	archived      map[string]bool
+	failures      map[string]string
+	requests      []string
+	drops         map[string]drop
+}
+
+// Connections to close instead of answering requests
+type drop struct {
+	count    int
+	unserved bool
+}
+
+// Starts a server with the "Default" workspace, accepting admin/admin.
+func NewServer() *Server {
+	s := &Server{
+		Username:      "admin",
+		Password:      "admin",
+		PendingPolls:  1,
+		objects:       make(map[string]map[int]map[string]interface{}),
+		nextIDs:       make(map[string]int),
+		jobs:          make(map[int]*job),
+		sessionTokens: make(map[string]bool),
+		provisioners:  defaultProvisioners(),
+		archived:      map[string]bool{"scriptingDeployments": true},
+		failures:      make(map[string]string),
+		drops:         make(map[string]drop),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	s.Add("workspaces", map[string]interface{}{"name": "Default"})
+
+	return s
+}
+
+// Returns the scheme, host and port of the server, the way the provider is configured.
+func (s *Server) Address() (scheme string, host string, port string) {
+	serverURL, _ := url.Parse(s.URL)
+	return serverURL.Scheme, serverURL.Hostname(), serverURL.Port()
+}
+
+// Returns the path OneFuse uses to link to an object.
+func Href(resourceType string, id int) string {
+	return fmt.Sprintf("%s%s/%d/", ApiPrefix, resourceType, id)
+}
+
+// Stores a copy of object under the next ID for resourceType and returns the stored copy.
+func (s *Server) Add(resourceType string, object map[string]interface{}) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.add(resourceType, object)
+}
+
+// Returns a copy of the object, or nil if it does not exist.
+func (s *Server) Get(resourceType string, id int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[resourceType][id]
+	if !ok {
+		return nil
+	}
+	return copyObject(object)
+}
+
+// Deletes the object as if it was removed in the OneFuse UI.
+func (s *Server) Remove(resourceType string, id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects[resourceType], id)
+}
+
+// Replaces what the server fills in when it provisions a managed object of resourceType.
+func (s *Server) SetProvisioner(resourceType string, provision ProvisionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.provisioners[resourceType] = provision
+}
+
+// Makes jobs for resourceType fail with message, or succeed again when message is empty.
+func (s *Server) FailJobs(resourceType string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if message == "" {
+		delete(s.failures, resourceType)
+		return
+	}
+	s.failures[resourceType] = message
+}
+
+// Closes the connection instead of answering the next count method requests for resourceType,
+// after acting on them, the way a proxy that loses the response does.
+func (s *Server) DropResponses(method string, resourceType string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drops[method+" "+resourceType] = drop{count: count}
+}
+
+// Closes the connection without acting on the next count method requests for resourceType.
+func (s *Server) DropRequests(method string, resourceType string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drops[method+" "+resourceType] = drop{count: count, unserved: true}
+}
+
+// Returns the requests received so far as "METHOD /path?query".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) add(resourceType string, object map[string]interface{}) map[string]interface{} {
+	if s.objects[resourceType] == nil {
+		s.objects[resourceType] = make(map[int]map[string]interface{})
+	}
+	s.nextIDs[resourceType]++
+	id := s.nextIDs[resourceType]
+
+	stored := copyObject(object)
+	stored["id"] = id
+	setLink(stored, "self", Href(resourceType, id))
+	s.objects[resourceType][id] = stored
+
+	return copyObject(stored)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		request += "?" + r.URL.RawQuery
+	}
+	s.requests = append(s.requests, request)
+
+	if !strings.HasPrefix(r.URL.Path, ApiPrefix) {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, ApiPrefix), "/"), "/")
+	resourceType := parts[0]
+
+	if resourceType == "apiToken" && r.Method == http.MethodPost {
+		s.issueToken(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return
+	}
+
+	if d := s.drops[r.Method+" "+resourceType]; d.count > 0 {
+		d.count--
+		s.drops[r.Method+" "+resourceType] = d
+
+		conn := w
+		defer closeConnection(conn)
+		if d.unserved {
+			return
+		}
+		w = httptest.NewRecorder()
+	}
+
+	body := map[string]interface{}{}
+	if r.Body != nil {
+		if b, _ := ioutil.ReadAll(r.Body); len(b) > 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("JSON parse error - %s", err))
+				return
+			}
+		}
+	}
+
+	if len(parts) == 1 {
+		switch {
+		case resourceType == "templateTester" && r.Method == http.MethodPost:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"value": renderTemplate(body)})
+		case r.Method == http.MethodGet:
+			s.list(w, r, resourceType)
+		case r.Method == http.MethodPost && s.isManaged(resourceType):
+			s.startJob(w, "Deploy", resourceType, 0, body)
+		case r.Method == http.MethodPost:
+			writeJSON(w, http.StatusCreated, withoutWriteOnly(s.add(resourceType, s.withWorkspaceTitle(withLinks(body)))))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	if resourceType == "jobStatus" && r.Method == http.MethodGet {
+		s.pollJob(w, id)
+		return
+	}
+
+	object, ok := s.objects[resourceType][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, withoutWriteOnly(object))
+	case r.Method == http.MethodPut && s.isManaged(resourceType):
+		s.startJob(w, "Update", resourceType, id, body)
+	case r.Method == http.MethodPut:
+		// Like OneFuse, fields left out of the body keep their values and links sent back are ignored
+		merged := copyObject(object)
+		for field, value := range body {
+			merged[field] = value
+		}
+		delete(merged, "_links")
+		updated := s.withWorkspaceTitle(withLinks(merged))
+		updated["id"] = id
+		setLink(updated, "self", Href(resourceType, id))
+		s.objects[resourceType][id] = updated
+		writeJSON(w, http.StatusOK, withoutWriteOnly(updated))
+	case r.Method == http.MethodDelete && s.isManaged(resourceType):
+		s.startJob(w, "Delete", resourceType, id, nil)
+	case r.Method == http.MethodDelete:
+		delete(s.objects[resourceType], id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if user, password, ok := r.BasicAuth(); ok {
+		return user == s.Username && password == s.Password
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return false
+	}
+	return (s.Token != "" && token == s.Token) || s.sessionTokens[token]
+}
+
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	credentials := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil ||
+		credentials.Username != s.Username || credentials.Password != s.Password {
+		writeError(w, http.StatusUnauthorized, "Unable to log in with provided credentials.")
+		return
+	}
+
+	token := fmt.Sprintf("session-token-%d", len(s.sessionTokens)+1)
+	s.sessionTokens[token] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{"token": token})
+}
+
+func (s *Server) isManaged(resourceType string) bool {
+	for _, managedType := range ManagedObjectTypes {
+		if managedType == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Applies the change right away and answers with the job that reports it. The managed object
+// is linked from the job, and its resolved properties are recorded as the job's metadata.
+func (s *Server) startJob(w http.ResponseWriter, action string, resourceType string, id int, body map[string]interface{}) {
+	j := &job{
+		jobType:      fmt.Sprintf("%s %s", action, resourceType),
+		pendingPolls: s.PendingPolls,
+		failure:      s.failures[resourceType],
+	}
+
+	templateProperties, _ := body["templateProperties"].(map[string]interface{})
+	metadata := s.add("jobMetadata", map[string]interface{}{"resolvedProperties": templateProperties})
+	j.metadataID = metadata["id"].(int)
+
+	if j.failure == "" {
+		switch action {
+		case "Deploy":
+			object := s.add(resourceType, s.withWorkspaceTitle(withLinks(body)))
+			id = object["id"].(int)
+			s.provision(resourceType, id, j.metadataID)
+		case "Update":
+			updated := s.withWorkspaceTitle(withLinks(body))
+			updated["id"] = id
+			setLink(updated, "self", Href(resourceType, id))
+			s.objects[resourceType][id] = updated
+			s.provision(resourceType, id, j.metadataID)
+		case "Delete":
+			if s.archived[resourceType] {
+				s.objects[resourceType][id]["archived"] = true
+			} else {
+				delete(s.objects[resourceType], id)
+			}
+		}
+	}
+	if id != 0 {
+		j.managedObject = Href(resourceType, id)
+	}
+
+	s.nextJobID++
+	j.id = s.nextJobID
+	s.jobs[j.id] = j
+
+	writeJSON(w, http.StatusAccepted, s.jobStatus(j, JobPending))
+}
+
+func (s *Server) provision(resourceType string, id int, metadataID int) {
+	object := s.objects[resourceType][id]
+	setLink(object, "jobMetadata", Href("jobMetadata", metadataID))
+	if policy := linkHref(object, "policy"); policy != "" {
+		if policyID, err := strconv.Atoi(path(policy)[1]); err == nil {
+			object["policyId"] = policyID
+		}
+	}
+	if provision := s.provisioners[resourceType]; provision != nil {
+		provision(id, object)
+	}
+}
+
+func (s *Server) pollJob(w http.ResponseWriter, id int) {
+	j, ok := s.jobs[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	state := JobSuccess
+	if j.pendingPolls > 0 {
+		j.pendingPolls--
+		state = JobPending
+	} else if j.failure != "" {
+		state = JobFailed
+	}
+
+	writeJSON(w, http.StatusOK, s.jobStatus(j, state))
+}
+
+func (s *Server) jobStatus(j *job, state string) map[string]interface{} {
+	links := map[string]interface{}{
+		"self":        map[string]interface{}{"href": Href("jobStatus", j.id)},
+		"jobMetadata": map[string]interface{}{"href": Href("jobMetadata", j.metadataID)},
+	}
+	if j.managedObject != "" {
+		links["managedObject"] = map[string]interface{}{"href": j.managedObject}
+	}
+
+	status := map[string]interface{}{
+		"_links":        links,
+		"id":            j.id,
+		"jobState":      state,
+		"jobType":       j.jobType,
+		"jobTrackingId": fmt.Sprintf("fake-job-%d", j.id),
+	}
+	if state == JobFailed {
+		status["errorDetails"] = map[string]interface{}{
+			"code":   500,
+			"errors": []interface{}{map[string]interface{}{"message": j.failure}},
+		}
+	}
+	return status
+}
+
+// Serves a HAL+JSON collection. The filter query takes ";" separated "field:value" terms, which
+// match values containing value, and "field.exact:value" terms, which match the whole value.
+// "workspace.id:value" terms match the ID of the referenced workspace.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, resourceType string) {
+	filters := parseFilter(r.URL.RawQuery)
+
+	ids := make([]int, 0, len(s.objects[resourceType]))
+	for id, object := range s.objects[resourceType] {
+		if matchesFilters(object, filters) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	items := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, withoutWriteOnly(s.objects[resourceType][id]))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"_links":    map[string]interface{}{"self": map[string]interface{}{"href": ApiPrefix + resourceType + "/"}},
+		"_embedded": map[string]interface{}{resourceType: items},
+		"count":     len(items),
+	})
+}
+
+type filter struct {
+	field string
+	value string
+	exact bool
+}
+
+// OneFuse separates filter terms with ";", which net/url no longer accepts in queries,
+// so the raw query is split by hand.
+func parseFilter(rawQuery string) []filter {
+	var filters []filter
+	for _, param := range strings.Split(rawQuery, "&") {
+		if !strings.HasPrefix(param, "filter=") {
+			continue
+		}
+		value, err := url.QueryUnescape(strings.TrimPrefix(param, "filter="))
+		if err != nil {
+			continue
+		}
+		for _, term := range strings.Split(value, ";") {
+			fieldAndValue := strings.SplitN(term, ":", 2)
+			if len(fieldAndValue) != 2 {
+				continue
+			}
+			f := filter{field: fieldAndValue[0], value: fieldAndValue[1]}
+			if strings.HasSuffix(f.field, ".exact") {
+				f.field = strings.TrimSuffix(f.field, ".exact")
+				f.exact = true
+			}
+			filters = append(filters, f)
+		}
+	}
+	return filters
+}
+
+func matchesFilters(object map[string]interface{}, filters []filter) bool {
+	for _, f := range filters {
+		value := filterValue(object, f.field)
+		if f.exact && value != f.value {
+			return false
+		}
+		if !f.exact && !strings.Contains(strings.ToLower(value), strings.ToLower(f.value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the field's value as a string. "reference.id" fields, such as "workspace.id", are
+// the ID at the end of the reference's URL.
+func filterValue(object map[string]interface{}, field string) string {
+	if reference := strings.TrimSuffix(field, ".id"); reference != field {
+		href, _ := object[reference].(string)
+		return path(href)[1]
+	}
+	return fmt.Sprintf("%v", object[field])
+}
+
+var templateVariable = regexp.MustCompile(`{{\s*([\w.]+)\s*}}`)
+
+// Substitutes "{{ name }}" with the matching template property, enough for simple templates.
+func renderTemplate(body map[string]interface{}) string {
+	template, _ := body["template"].(string)
+	properties, _ := body["template_properties"].(map[string]interface{})
+
+	return templateVariable.ReplaceAllStringFunc(template, func(match string) string {
+		name := templateVariable.FindStringSubmatch(match)[1]
+		if value, ok := properties[name]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+		return ""
+	})
+}
+
+// References OneFuse accepts as a URL, such as "policy", and links as "_links".
+var urlReferences = []string{"policy", "workspace", "endpoint", "credential", "microsoftEndpoint", "namingSequence"}
+
+// Returns the object with "_links" to the policy, workspace and endpoint it refers to.
+// References may be full URLs; links are always paths, as OneFuse returns them.
+func withLinks(body map[string]interface{}) map[string]interface{} {
+	object := copyObject(body)
+	for _, field := range urlReferences {
+		if reference, ok := object[field].(string); ok && reference != "" {
+			href := reference
+			if referenceURL, err := url.Parse(reference); err == nil {
+				href = referenceURL.Path
+			}
+			object[field] = href
+			setLink(object, field, href)
+		}
+	}
+	for field, resourceType := range idReferences {
+		if id, ok := object[field+"Id"].(float64); ok && id != 0 {
+			setLink(object, field, Href(resourceType, int(id)))
+		}
+	}
+	for _, field := range urlListReferences {
+		references, ok := object[field].([]interface{})
+		if !ok {
+			continue
+		}
+		var hrefs []interface{}
+		var links []interface{}
+		for _, reference := range references {
+			href, _ := reference.(string)
+			if referenceURL, err := url.Parse(href); err == nil {
+				href = referenceURL.Path
+			}
+			hrefs = append(hrefs, href)
+			links = append(links, map[string]interface{}{"href": href})
+		}
+		object[field] = hrefs
+		setLinks(object, field, links)
+	}
+	return object
+}
+
+// References to several objects, such as a Module Policy's "credentials", linked as a list.
+var urlListReferences = []string{"credentials"}
+
+// Titles the workspace link with the workspace's name, as OneFuse does.
+func (s *Server) withWorkspaceTitle(object map[string]interface{}) map[string]interface{} {
+	links, _ := object["_links"].(map[string]interface{})
+	link, _ := links["workspace"].(map[string]interface{})
+	if link == nil {
+		return object
+	}
+	href, _ := link["href"].(string)
+	id, _ := strconv.Atoi(path(href)[1])
+	if workspace, ok := s.objects["workspaces"][id]; ok {
+		link["title"] = workspace["name"]
+	}
+	return object
+}
+
+// Fields OneFuse accepts but never returns, such as a credential's password.
+// An update without them keeps the stored value.
+var writeOnlyFields = []string{"password"}
+
+func withoutWriteOnly(object map[string]interface{}) map[string]interface{} {
+	public := copyObject(object)
+	for _, field := range writeOnlyFields {
+		delete(public, field)
+	}
+	return public
+}
+
+// References OneFuse accepts as an ID, such as "microsoftEndpointId", and links like the URL form.
+var idReferences = map[string]string{
+	"microsoftEndpoint": "endpoints",
+}
+
+// Splits an href below the API prefix into its resource type and ID.
+func path(href string) []string {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(href, ApiPrefix), "/"), "/")
+	for len(parts) < 2 {
+		parts = append(parts, "")
+	}
+	return parts
+}
+
+func setLink(object map[string]interface{}, name string, href string) {
+	setLinks(object, name, map[string]interface{}{"href": href})
+}
+
+func setLinks(object map[string]interface{}, name string, link interface{}) {
+	links, ok := object["_links"].(map[string]interface{})
+	if !ok {
+		links = make(map[string]interface{})
+		object["_links"] = links
+	}
+	links[name] = link
+}
+
+func linkHref(object map[string]interface{}, name string) string {
+	links, _ := object["_links"].(map[string]interface{})
+	link, _ := links[name].(map[string]interface{})
+	href, _ := link["href"].(string)
+	return href
+}
+
+// Deep copies the object through JSON so callers never share maps with the server.
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	b, _ := json.Marshal(object)
+	copied := make(map[string]interface{})
+	json.Unmarshal(b, &copied)
+	for key, value := range object {
+		if id, ok := value.(int); ok {
+			copied[key] = id
+		}
+	}
+	return copied
+}
+
+// Closes the client's connection without writing a response.
+func closeConnection(w http.ResponseWriter) {
+	if conn, _, err := w.(http.Hijacker).Hijack(); err == nil {
+		conn.Close()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]interface{}{"detail": detail})
+}
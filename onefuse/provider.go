@@ -7,11 +7,22 @@
 package onefuse
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pkg/errors"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"scheme": {
 				Type:        schema.TypeString,
@@ -33,23 +44,129 @@ func Provider() *schema.Provider {
 			},
 			"user": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_USER", nil),
 				Description: "OneFuse REST endpoint user name",
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_PASSWORD", nil),
 				Description: "OneFuse REST endpoint password",
 			},
+			"auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "OneFuse REST endpoint authentication settings",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								AuthMethodToken,
+								AuthMethodSession,
+								AuthMethodBasic,
+							}, false),
+							Description: "One of token, session or basic. Defaults to token when a token is set, otherwise basic",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_TOKEN", nil),
+							Description: "OneFuse API token",
+						},
+					},
+				},
+			},
 			"verify_ssl": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_VERIFY_SSL", true),
 				Description: "Verify SSL certificates for OneFuse endpoints",
 			},
+			"ca_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_CA_CERT", nil),
+				Description: "PEM encoded CA bundle, or a path to one, used to verify OneFuse endpoints",
+			},
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_CLIENT_CERT", nil),
+				Description: "PEM encoded client certificate, or a path to one, for mutual TLS",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_CLIENT_KEY", nil),
+				Description: "PEM encoded client private key, or a path to one, for mutual TLS",
+			},
+			"default_workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ONEFUSE_WORKSPACE", DefaultWorkspaceName),
+				Description: "Name or ID of the workspace used by resources that do not set one",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "HTTP(S) proxy for OneFuse requests. Defaults to the HTTPS_PROXY environment variable",
+			},
+			"job_poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DefaultJobPollInterval.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Initial wait between OneFuse job status checks, for example \"2s\"",
+			},
+			"job_poll_max_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DefaultJobPollMaxInterval.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Longest wait between OneFuse job status checks, for example \"30s\"",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a request is retried after a connection failure or a 429, 502, 503 or 504 response",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DefaultRetryWaitMin.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Wait before the first retry, doubled for every further retry",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DefaultRetryWaitMax.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Longest wait between retries unless OneFuse asks for more with Retry-After",
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxIdleConns,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of idle keep-alive connections to OneFuse",
+			},
+			"max_conns_per_host": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxConnsPerHost,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of connections to OneFuse, 0 means no limit",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"onefuse_naming":                        resourceCustomNaming(),
@@ -62,6 +179,25 @@ func Provider() *schema.Provider {
 			"onefuse_vra_deployment":                resourceVraDeployment(),
 			"onefuse_servicenow_cmdb_deployment":    resourceServicenowCMDBDeployment(),
 			"onefuse_module_deployment":             resourceModuleDeployment(),
+			"onefuse_naming_policy":                 resourceNamingPolicy(),
+			"onefuse_ipam_policy":                   resourceIPAMPolicy(),
+			"onefuse_dns_policy":                    resourceDNSPolicy(),
+			"onefuse_scripting_policy":              resourceScriptingPolicy(),
+			"onefuse_ansible_tower_policy":          resourceAnsibleTowerPolicy(),
+			"onefuse_vra_policy":                    resourceVraPolicy(),
+			"onefuse_servicenow_cmdb_policy":        resourceServicenowCMDBPolicy(),
+			"onefuse_module_policy":                 resourceModulePolicy(),
+			"onefuse_microsoft_endpoint":            resourceMicrosoftEndpoint(),
+			"onefuse_infoblox_endpoint":             resourceEndpoint(InfobloxEndpointType, "Infoblox Endpoint"),
+			"onefuse_bluecat_endpoint":              resourceEndpoint(BluecatEndpointType, "BlueCat Endpoint"),
+			"onefuse_menandmice_endpoint":           resourceEndpoint(MenAndMiceEndpointType, "Men&Mice Endpoint"),
+			"onefuse_ansible_tower_endpoint":        resourceEndpoint(AnsibleTowerEndpointType, "Ansible Tower Endpoint"),
+			"onefuse_vra_endpoint":                  resourceEndpoint(VraEndpointType, "vRA Endpoint"),
+			"onefuse_servicenow_endpoint":           resourceEndpoint(ServicenowEndpointType, "ServiceNow Endpoint"),
+			"onefuse_module_endpoint":               resourceEndpoint(ModuleEndpointType, "Module Endpoint"),
+			"onefuse_credential":                    resourceCredential(),
+			"onefuse_workspace":                     resourceWorkspace(),
+			"onefuse_static_property_set":           resourceStaticPropertySet(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"onefuse_microsoft_endpoint":     dataSourceMicrosoftEndpoint(),
@@ -76,29 +212,154 @@ func Provider() *schema.Provider {
 			"onefuse_servicenow_cmdb_policy": dataSourceServicenowCMDBPolicy(),
 			"onefuse_module_policy":          dataSourceModulePolicy(),
 			"onefuse_vra_policy":             dataSourceVraPolicy(),
+			"onefuse_infoblox_endpoint":      dataSourceEndpoint(InfobloxEndpointType, "Infoblox Endpoint"),
+			"onefuse_bluecat_endpoint":       dataSourceEndpoint(BluecatEndpointType, "BlueCat Endpoint"),
+			"onefuse_menandmice_endpoint":    dataSourceEndpoint(MenAndMiceEndpointType, "Men&Mice Endpoint"),
+			"onefuse_ansible_tower_endpoint": dataSourceEndpoint(AnsibleTowerEndpointType, "Ansible Tower Endpoint"),
+			"onefuse_vra_endpoint":           dataSourceEndpoint(VraEndpointType, "vRA Endpoint"),
+			"onefuse_servicenow_endpoint":    dataSourceEndpoint(ServicenowEndpointType, "ServiceNow Endpoint"),
+			"onefuse_module_endpoint":        dataSourceEndpoint(ModuleEndpointType, "Module Endpoint"),
+			"onefuse_workspace":              dataSourceWorkspace(),
 		},
-		ConfigureFunc: configureProvider,
 	}
+
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		config, err := configureProvider(d)
+		if err != nil {
+			return nil, err
+		}
+		// Lets job polling stop when Terraform is interrupted
+		config.stopContext = provider.StopContext
+		return config, nil
+	}
+
+	return provider
 }
 
 type Config struct {
-	scheme    string
-	address   string
-	port      string
-	user      string
-	password  string
-	verifySSL bool
+	scheme     string
+	address    string
+	port       string
+	user       string
+	password   string
+	verifySSL  bool
+	auth       *apiAuth
+	tlsConfig  *tls.Config
+	httpClient *http.Client
+
+	defaultWorkspaceURL string
+
+	pollInterval    time.Duration
+	pollMaxInterval time.Duration
+	jobTimeout      time.Duration
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+	stopContext  func() context.Context
 }
 
-func configureProvider(d *schema.ResourceData) (interface{}, error) {
-	return NewConfig(
+func configureProvider(d *schema.ResourceData) (Config, error) {
+	config := NewConfig(
 		d.Get("scheme").(string),
 		d.Get("address").(string),
 		d.Get("port").(string),
 		d.Get("user").(string),
 		d.Get("password").(string),
 		d.Get("verify_ssl").(bool),
-	), nil
+	)
+
+	auth, err := configureAuth(d)
+	if err != nil {
+		return config, err
+	}
+	config.auth = auth
+
+	tlsConfig, err := buildTLSConfig(
+		config.verifySSL,
+		d.Get("ca_cert").(string),
+		d.Get("client_cert").(string),
+		d.Get("client_key").(string),
+	)
+	if err != nil {
+		return config, err
+	}
+	config.tlsConfig = tlsConfig
+
+	var proxyURL *url.URL
+	if proxy := d.Get("proxy_url").(string); proxy != "" {
+		if proxyURL, err = url.Parse(proxy); err != nil {
+			return config, errors.WithMessage(err, fmt.Sprintf("onefuse.configureProvider: Invalid proxy_url '%s'", proxy))
+		}
+	}
+	if config.pollInterval, err = time.ParseDuration(d.Get("job_poll_interval").(string)); err != nil {
+		return config, errors.WithMessage(err, "onefuse.configureProvider: Invalid job_poll_interval")
+	}
+	if config.pollMaxInterval, err = time.ParseDuration(d.Get("job_poll_max_interval").(string)); err != nil {
+		return config, errors.WithMessage(err, "onefuse.configureProvider: Invalid job_poll_max_interval")
+	}
+
+	config.maxRetries = d.Get("max_retries").(int)
+	if config.retryWaitMin, err = time.ParseDuration(d.Get("retry_wait_min").(string)); err != nil {
+		return config, errors.WithMessage(err, "onefuse.configureProvider: Invalid retry_wait_min")
+	}
+	if config.retryWaitMax, err = time.ParseDuration(d.Get("retry_wait_max").(string)); err != nil {
+		return config, errors.WithMessage(err, "onefuse.configureProvider: Invalid retry_wait_max")
+	}
+
+	config.httpClient = newHttpClient(&config, d.Get("max_idle_conns").(int), d.Get("max_conns_per_host").(int), proxyURL)
+
+	defaultWorkspace := d.Get("default_workspace").(string)
+	if config.defaultWorkspaceURL, err = configureDefaultWorkspace(&config, defaultWorkspace); err != nil {
+		return config, errors.WithMessage(err, fmt.Sprintf("onefuse.configureProvider: Cannot find default_workspace '%s'", defaultWorkspace))
+	}
+
+	return config, nil
+}
+
+// Resolves the default workspace once, so resources do not look it up on every create.
+func configureDefaultWorkspace(config *Config, workspace string) (string, error) {
+	workspaceURL, err := findWorkspaceURLOrDefault(config, workspace)
+	if err != nil {
+		return "", err
+	}
+
+	// IDs and URLs are not looked up when they are resolved
+	url := workspaceURL
+	if strings.HasPrefix(url, "/") {
+		url = urlFromHref(config, url)
+	}
+	if err = doGet(config, url, &Workspace{}); err != nil {
+		return "", err
+	}
+	return workspaceURL, nil
+}
+
+func configureAuth(d *schema.ResourceData) (*apiAuth, error) {
+	method := d.Get("auth.0.method").(string)
+	token := d.Get("auth.0.token").(string)
+	if token == "" {
+		// The block's default only applies when the block is present
+		token = os.Getenv("ONEFUSE_TOKEN")
+	}
+
+	if method == "" {
+		if token != "" {
+			method = AuthMethodToken
+		} else {
+			method = AuthMethodBasic
+		}
+	}
+
+	if method == AuthMethodToken {
+		if token == "" {
+			return nil, errors.New("onefuse.configureProvider: auth method 'token' requires a token or ONEFUSE_TOKEN")
+		}
+	} else if d.Get("user").(string) == "" || d.Get("password").(string) == "" {
+		return nil, errors.New(fmt.Sprintf("onefuse.configureProvider: auth method '%s' requires user and password", method))
+	}
+
+	return newAPIAuth(method, token), nil
 }
 
 func NewConfig(scheme string, address string, port string, user string, password string, verifySSL bool) Config {
@@ -109,5 +370,26 @@ func NewConfig(scheme string, address string, port string, user string, password
 		user:      user,
 		password:  password,
 		verifySSL: verifySSL,
+
+		maxRetries:   DefaultMaxRetries,
+		retryWaitMin: DefaultRetryWaitMin,
+		retryWaitMax: DefaultRetryWaitMax,
+	}
+}
+
+func (c *Config) context() context.Context {
+	if c.stopContext == nil {
+		return context.Background()
+	}
+	return c.stopContext()
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errs []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration such as \"5s\": %s", k, err))
+	} else if duration <= 0 {
+		errs = append(errs, fmt.Errorf("%q must be greater than zero", k))
 	}
+	return
 }
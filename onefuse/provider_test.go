@@ -0,0 +1,245 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pkg/errors"
+)
+
+var testAccProvider *schema.Provider
+var testAccProviders map[string]terraform.ResourceProvider
+
+func init() {
+	testAccProvider = Provider()
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"onefuse": testAccProvider,
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("Error validating provider: '%s'", err)
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	if fakeServer == nil && getEnv("CB_ONEFUSE_CFG_ADDRESS", "") == "" {
+		t.Fatal("CB_ONEFUSE_CFG_ADDRESS must be set for acceptance tests against a live appliance")
+	}
+}
+
+// Returns the provider block for the fake OneFuse server, or for the live appliance.
+func testAccProviderConfig() string {
+	config := GetConfig()
+
+	pollInterval := DefaultJobPollInterval
+	if fakeServer != nil {
+		pollInterval = config.pollInterval
+	}
+
+	return fmt.Sprintf(`
+provider "onefuse" {
+  scheme            = %q
+  address           = %q
+  port              = %q
+  user              = %q
+  password          = %q
+  verify_ssl        = false
+  job_poll_interval = %q
+}
+`, config.scheme, config.address, config.port, config.user, config.password, pollInterval)
+}
+
+// Returns the ID OneFuse knows the resource by. Most resources use it as their Terraform ID.
+func testAccOneFuseID(rs *terraform.ResourceState) (int, error) {
+	if customNameID, ok := rs.Primary.Attributes["custom_name_id"]; ok {
+		return strconv.Atoi(customNameID)
+	}
+	return strconv.Atoi(rs.Primary.ID)
+}
+
+// Checks that every resource of resourceType in the state is gone from OneFuse.
+func testAccCheckDestroyed(resourceType string, get func(apiClient *OneFuseAPIClient, id int) error) resource.TestCheckFunc {
+	return testAccCheckDestroyedOrArchived(resourceType, func(apiClient *OneFuseAPIClient, id int) (bool, error) {
+		return false, get(apiClient, id)
+	})
+}
+
+// Like testAccCheckDestroyed, for managed objects that OneFuse archives instead of deleting.
+// getArchived returns the object's archived flag.
+func testAccCheckDestroyedOrArchived(resourceType string, getArchived func(apiClient *OneFuseAPIClient, id int) (bool, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := GetConfig()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			id, err := testAccOneFuseID(rs)
+			if err != nil {
+				return err
+			}
+
+			archived, err := getArchived(config.NewOneFuseApiClient(), id)
+			if IsNotFound(err) || (err == nil && archived) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			return errors.New(fmt.Sprintf("%s %d still exists", resourceType, id))
+		}
+
+		return nil
+	}
+}
+
+// Checks that the resource exists in OneFuse.
+func testAccCheckExists(name string, get func(apiClient *OneFuseAPIClient, id int) error) resource.TestCheckFunc {
+	return testAccCheckExistsNotArchived(name, func(apiClient *OneFuseAPIClient, id int) (bool, error) {
+		return false, get(apiClient, id)
+	})
+}
+
+// Like testAccCheckExists, for managed objects that OneFuse archives instead of deleting.
+// getArchived returns the object's archived flag.
+func testAccCheckExistsNotArchived(name string, getArchived func(apiClient *OneFuseAPIClient, id int) (bool, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return errors.New(fmt.Sprintf("Not found: %s", name))
+		}
+
+		id, err := testAccOneFuseID(rs)
+		if err != nil {
+			return err
+		}
+
+		config := GetConfig()
+		archived, err := getArchived(config.NewOneFuseApiClient(), id)
+		if err != nil {
+			return err
+		}
+		if archived {
+			return errors.New(fmt.Sprintf("%s %d is archived", name, id))
+		}
+		return nil
+	}
+}
+
+// Checks that OneFuse links the deployment to a policy of policyResourceType.
+// getPolicyHref returns the policy link of the deployment.
+func testAccCheckDeploymentPolicyLink(name string, policyResourceType string, getPolicyHref func(apiClient *OneFuseAPIClient, id int) (string, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return errors.New(fmt.Sprintf("Not found: %s", name))
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		config := GetConfig()
+		policyHref, err := getPolicyHref(config.NewOneFuseApiClient(), id)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(policyHref, "/"+policyResourceType+"/") {
+			return errors.New(fmt.Sprintf("Expected %s to link to one of %s but got '%s'", name, policyResourceType, policyHref))
+		}
+		return nil
+	}
+}
+
+func testProviderResourceData(t *testing.T, defaultWorkspace string) *schema.ResourceData {
+	config := GetConfig()
+
+	raw := map[string]interface{}{
+		"scheme":     config.scheme,
+		"address":    config.address,
+		"port":       config.port,
+		"user":       config.user,
+		"password":   config.password,
+		"verify_ssl": false,
+	}
+	if defaultWorkspace != "" {
+		raw["default_workspace"] = defaultWorkspace
+	}
+	return schema.TestResourceDataRaw(t, Provider().Schema, raw)
+}
+
+func TestProviderDefaultWorkspace(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	workspace, err := apiClient.CreateWorkspace(&Workspace{Name: "tfProviderDefaultWorkspace"})
+	if err != nil {
+		t.Fatalf("Error creating Workspace: '%s'", err)
+	}
+	defer apiClient.DeleteWorkspace(workspace.ID)
+
+	expected := itemURL(&config, WorkspaceResourceType, workspace.ID)
+	for _, defaultWorkspace := range []string{workspace.Name, strconv.Itoa(workspace.ID)} {
+		providerConfig, err := configureProvider(testProviderResourceData(t, defaultWorkspace))
+		if err != nil {
+			t.Fatalf("Error configuring provider with default_workspace '%s': '%s'", defaultWorkspace, err)
+		}
+		if providerConfig.defaultWorkspaceURL != expected {
+			t.Errorf("Bad default workspace for '%s'; expected '%s' but got '%s'", defaultWorkspace, expected, providerConfig.defaultWorkspaceURL)
+		}
+
+		// Resources without a workspace inherit the default
+		credential, err := providerConfig.NewOneFuseApiClient().CreateCredential(&Credential{
+			Name:     "tfProviderDefaultWorkspaceCredential",
+			Username: "svc_onefuse",
+			Password: "Secret01!",
+		})
+		if err != nil {
+			t.Fatalf("Error creating Credential: '%s'", err)
+		}
+		if !strings.HasSuffix(expected, credential.Links.Workspace.Href) {
+			t.Errorf("Bad workspace for Credential; expected '%s' but got '%s'", expected, credential.Links.Workspace.Href)
+		}
+		apiClient.DeleteCredential(credential.ID)
+	}
+}
+
+func TestProviderDefaultWorkspaceDefault(t *testing.T) {
+	providerConfig, err := configureProvider(testProviderResourceData(t, ""))
+	if err != nil {
+		t.Fatalf("Error configuring provider: '%s'", err)
+	}
+
+	config := GetConfig()
+	defaultWorkspaceID, err := findDefaultWorkspaceID(&config)
+	if err != nil {
+		t.Fatalf("Error finding the Default workspace: '%s'", err)
+	}
+	if !strings.HasSuffix(providerConfig.defaultWorkspaceURL, "/"+defaultWorkspaceID+"/") {
+		t.Errorf("Expected the Default workspace but got '%s'", providerConfig.defaultWorkspaceURL)
+	}
+}
+
+func TestProviderDefaultWorkspaceMissing(t *testing.T) {
+	for _, defaultWorkspace := range []string{"tfMissingWorkspace", "99999"} {
+		_, err := configureProvider(testProviderResourceData(t, defaultWorkspace))
+		if err == nil || !strings.Contains(err.Error(), "default_workspace '"+defaultWorkspace+"'") {
+			t.Errorf("Expected an error naming default_workspace '%s' but got '%v'", defaultWorkspace, err)
+		}
+	}
+}
@@ -70,10 +70,10 @@ func resourceAnsibleTowerDeployment() *schema.Resource {
 	}
 }
 
-func bindAnsibleTowerDeploymentResource(d *schema.ResourceData, ansibleDeployment *AnsibleTowerDeployment) error {
+func bindAnsibleTowerDeploymentResource(config *Config, d *schema.ResourceData, ansibleDeployment *AnsibleTowerDeployment) error {
 	log.Println("onefuse.bindAnsibleTowerDeploymentResource")
 
-	if err := d.Set("workspace_url", ansibleDeployment.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, ansibleDeployment.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+ansibleDeployment.Links.Workspace.Href)
 	}
 
@@ -127,13 +127,13 @@ func resourceAnsibleTowerDeploymentCreate(d *schema.ResourceData, m interface{})
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	ansibleDeployment, err := config.NewOneFuseApiClient().CreateAnsibleTowerDeployment(&newAnsibleTowerDeployment)
+	ansibleDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateAnsibleTowerDeployment(&newAnsibleTowerDeployment)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(ansibleDeployment.ID))
 
-	return bindAnsibleTowerDeploymentResource(d, ansibleDeployment)
+	return bindAnsibleTowerDeploymentResource(&config, d, ansibleDeployment)
 }
 
 func resourceAnsibleTowerDeploymentRead(d *schema.ResourceData, m interface{}) error {
@@ -148,11 +148,16 @@ func resourceAnsibleTowerDeploymentRead(d *schema.ResourceData, m interface{}) e
 	}
 
 	ansibleDeployment, err := config.NewOneFuseApiClient().GetAnsibleTowerDeployment(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceAnsibleTowerDeploymentRead: Ansible Tower Deployment %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindAnsibleTowerDeploymentResource(d, ansibleDeployment)
+	return bindAnsibleTowerDeploymentResource(&config, d, ansibleDeployment)
 }
 
 func resourceAnsibleTowerDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
@@ -172,7 +177,7 @@ func resourceAnsibleTowerDeploymentDelete(d *schema.ResourceData, m interface{})
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteAnsibleTowerDeployment(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteAnsibleTowerDeployment(intID)
 }
 
 func importAnsibleReservation(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -197,7 +202,7 @@ func importAnsibleReservation(d *schema.ResourceData, meta interface{}) ([]*sche
     }
 
     // Bind the Ansible reservatin
-    if err := bindAnsibleTowerDeploymentResource(d, ansibleRecord); err != nil {
+    if err := bindAnsibleTowerDeploymentResource(&config, d, ansibleRecord); err != nil {
         log.Printf("Error binding Ansible reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind Ansible reservation data")
     }
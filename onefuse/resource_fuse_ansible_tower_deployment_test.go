@@ -8,9 +8,11 @@ package onefuse
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -119,3 +121,63 @@ func TestResourceAnsibleTowerDeploymentDelete(t *testing.T) {
 		return
 	}
 }
+
+func TestAccResourceAnsibleTowerDeployment(t *testing.T) {
+	resourceName := "onefuse_ansible_tower_deployment.deployment"
+	ansibleTowerPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_ANSIBLE_TOWER_POLICY_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_ansible_tower_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetAnsibleTowerDeployment(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAnsibleTowerDeploymentConfig(ansibleTowerPolicyID, "tfacc01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetAnsibleTowerDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(ansibleTowerPolicyID)),
+					resource.TestCheckResourceAttr(resourceName, "limit", "tfacc01"),
+					resource.TestCheckResourceAttrSet(resourceName, "inventory_name"),
+					resource.TestCheckResourceAttrSet(resourceName, "provisioning_job_results"),
+				),
+			},
+			{
+				// Changing the limit replaces the Ansible Tower Deployment.
+				Config: testAccAnsibleTowerDeploymentConfig(ansibleTowerPolicyID, "tfacc02"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetAnsibleTowerDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "limit", "tfacc02"),
+				),
+			},
+			{
+				Config:            testAccAnsibleTowerDeploymentConfig(ansibleTowerPolicyID, "tfacc02"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccAnsibleTowerDeploymentConfig(ansibleTowerPolicyID int, limit string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ansible_tower_deployment" "deployment" {
+  policy_id = %d
+  limit     = %q
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, ansibleTowerPolicyID, limit)
+}
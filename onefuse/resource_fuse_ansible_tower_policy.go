@@ -0,0 +1,274 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceAnsibleTowerPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAnsibleTowerPolicyCreate,
+		Read:   resourceAnsibleTowerPolicyRead,
+		Update: resourceAnsibleTowerPolicyUpdate,
+		Delete: resourceAnsibleTowerPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ansible_tower_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"inventory": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Template for the name of the inventory hosts are added to",
+			},
+			"hosts": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "Templates for the hosts added to the inventory",
+			},
+			"limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template for the limit passed to the job templates",
+			},
+			"provisioning_job_template":   ansibleTowerJobTemplateSchema("Job templates launched when an Ansible Tower Deployment is created"),
+			"deprovisioning_job_template": ansibleTowerJobTemplateSchema("Job templates launched when an Ansible Tower Deployment is destroyed"),
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func ansibleTowerJobTemplateSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"extra_vars": {
+					Type:     schema.TypeMap,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func bindAnsibleTowerPolicyResource(config *Config, d *schema.ResourceData, policy *AnsibleTowerPolicy) error {
+	log.Println("onefuse.bindAnsibleTowerPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("inventory", policy.InventoryName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set inventory: '%s'", policy.InventoryName))
+	}
+
+	if err := d.Set("hosts", policy.Hosts); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set hosts: %#v", policy.Hosts))
+	}
+
+	if err := d.Set("limit", policy.Limit); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set limit: '%s'", policy.Limit))
+	}
+
+	if err := d.Set("provisioning_job_template", flattenAnsibleTowerJobTemplates(policy.ProvisioningJobTemplates)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set provisioning job templates: %#v", policy.ProvisioningJobTemplates))
+	}
+
+	if err := d.Set("deprovisioning_job_template", flattenAnsibleTowerJobTemplates(policy.DeprovisioningJobTemplates)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set deprovisioning job templates: %#v", policy.DeprovisioningJobTemplates))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointID, err := policy.Links.Endpoint.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("ansible_tower_endpoint_id", endpointID); err != nil {
+		return errors.WithMessage(err, "Cannot set ansible_tower_endpoint_id")
+	}
+
+	return nil
+}
+
+// Extra vars are set as strings, as Terraform maps only hold one type.
+func flattenAnsibleTowerJobTemplates(jobTemplates []AnsibleTowerJobTemplate) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, jobTemplate := range jobTemplates {
+		extraVars := make(map[string]interface{})
+		for name, value := range jobTemplate.ExtraVars {
+			extraVars[name] = fmt.Sprintf("%v", value)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"name":       jobTemplate.Name,
+			"extra_vars": extraVars,
+		})
+	}
+	return flattened
+}
+
+func expandAnsibleTowerJobTemplates(jobTemplates []interface{}) []AnsibleTowerJobTemplate {
+	var expanded []AnsibleTowerJobTemplate
+	for _, jobTemplate := range jobTemplates {
+		jobTemplateMap := jobTemplate.(map[string]interface{})
+		expanded = append(expanded, AnsibleTowerJobTemplate{
+			Name:      jobTemplateMap["name"].(string),
+			ExtraVars: jobTemplateMap["extra_vars"].(map[string]interface{}),
+		})
+	}
+	return expanded
+}
+
+func ansibleTowerPolicyFromResource(d *schema.ResourceData) AnsibleTowerPolicy {
+	var hosts []string
+	for _, host := range d.Get("hosts").([]interface{}) {
+		hosts = append(hosts, host.(string))
+	}
+
+	return AnsibleTowerPolicy{
+		Name:                       d.Get("name").(string),
+		Description:                d.Get("description").(string),
+		EndpointID:                 d.Get("ansible_tower_endpoint_id").(int),
+		InventoryName:              d.Get("inventory").(string),
+		Hosts:                      hosts,
+		Limit:                      d.Get("limit").(string),
+		ProvisioningJobTemplates:   expandAnsibleTowerJobTemplates(d.Get("provisioning_job_template").([]interface{})),
+		DeprovisioningJobTemplates: expandAnsibleTowerJobTemplates(d.Get("deprovisioning_job_template").([]interface{})),
+		WorkspaceURL:               d.Get("workspace_url").(string),
+	}
+}
+
+func resourceAnsibleTowerPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceAnsibleTowerPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := ansibleTowerPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateAnsibleTowerPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Ansible Tower Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceAnsibleTowerPolicyRead(d, m)
+}
+
+func resourceAnsibleTowerPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceAnsibleTowerPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetAnsibleTowerPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceAnsibleTowerPolicyRead: Ansible Tower Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Ansible Tower Policy")
+	}
+
+	return bindAnsibleTowerPolicyResource(&config, d, policy)
+}
+
+func resourceAnsibleTowerPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceAnsibleTowerPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("ansible_tower_endpoint_id") ||
+		d.HasChange("inventory") ||
+		d.HasChange("hosts") ||
+		d.HasChange("limit") ||
+		d.HasChange("provisioning_job_template") ||
+		d.HasChange("deprovisioning_job_template") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := ansibleTowerPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateAnsibleTowerPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Ansible Tower Policy")
+	}
+
+	return resourceAnsibleTowerPolicyRead(d, m)
+}
+
+func resourceAnsibleTowerPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceAnsibleTowerPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Ansible Tower Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteAnsibleTowerPolicy(intID)
+}
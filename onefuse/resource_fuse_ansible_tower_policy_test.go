@@ -0,0 +1,187 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceAnsibleTowerPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	ansibleTowerEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_ANSIBLE_TOWER_ENDPOINT_ID", "1"))
+	newPolicy := AnsibleTowerPolicy{
+		Name:          "tfAnsibleTowerPolicyCRUD",
+		Description:   "Created by the API client tests",
+		EndpointID:    ansibleTowerEndpointID,
+		InventoryName: "{{ environment }}",
+		Hosts:         []string{"{{ hostname }}"},
+		ProvisioningJobTemplates: []AnsibleTowerJobTemplate{
+			{Name: "configure", ExtraVars: map[string]interface{}{"role": "web"}},
+		},
+	}
+
+	policy, err := apiClient.CreateAnsibleTowerPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating Ansible Tower Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetAnsibleTowerPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting Ansible Tower Policy: '%s'", err)
+	}
+	if len(policy.ProvisioningJobTemplates) != 1 || policy.ProvisioningJobTemplates[0].ExtraVars["role"] != "web" {
+		t.Errorf("Bad provisioning job templates for Ansible Tower Policy; got %v", policy.ProvisioningJobTemplates)
+	}
+
+	policy.Limit = "{{ hostname }}"
+	updatedPolicy, err := apiClient.UpdateAnsibleTowerPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Ansible Tower Policy: '%s'", err)
+	}
+	if updatedPolicy.Limit != policy.Limit {
+		t.Errorf("Bad limit for updated Ansible Tower Policy; expected '%s' but got '%s'", policy.Limit, updatedPolicy.Limit)
+	}
+
+	// Without a limit, hosts and deprovisioning job templates, all are unset
+	policy.Limit = ""
+	policy.Hosts = nil
+	policy.DeprovisioningJobTemplates = nil
+	updatedPolicy, err = apiClient.UpdateAnsibleTowerPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Ansible Tower Policy: '%s'", err)
+	}
+	if updatedPolicy.Limit != "" || len(updatedPolicy.Hosts) != 0 || len(updatedPolicy.DeprovisioningJobTemplates) != 0 {
+		t.Errorf("Expected updated Ansible Tower Policy to have no limit, hosts or deprovisioning job templates but got '%s', %v and %v", updatedPolicy.Limit, updatedPolicy.Hosts, updatedPolicy.DeprovisioningJobTemplates)
+	}
+
+	if err = apiClient.DeleteAnsibleTowerPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting Ansible Tower Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetAnsibleTowerPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Ansible Tower Policy to be not found but got '%v'", err)
+	}
+}
+
+func getAnsibleTowerDeploymentPolicyHref(apiClient *OneFuseAPIClient, id int) (string, error) {
+	deployment, err := apiClient.GetAnsibleTowerDeployment(id)
+	if err != nil {
+		return "", err
+	}
+	return deployment.Links.Policy.Href, nil
+}
+
+func TestAccResourceAnsibleTowerPolicy(t *testing.T) {
+	resourceName := "onefuse_ansible_tower_policy.policy"
+	ansibleTowerEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_ANSIBLE_TOWER_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_ansible_tower_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetAnsibleTowerPolicy(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_ansible_tower_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetAnsibleTowerDeployment(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAnsibleTowerPolicyConfig(ansibleTowerEndpointID, "web"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetAnsibleTowerPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccAnsibleTowerPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "ansible_tower_endpoint_id", strconv.Itoa(ansibleTowerEndpointID)),
+					resource.TestCheckResourceAttr(resourceName, "inventory", "{{ environment }}"),
+					resource.TestCheckResourceAttr(resourceName, "hosts.0", "{{ hostname }}"),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_job_template.0.name", "configure"),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_job_template.0.extra_vars.role", "web"),
+					resource.TestCheckResourceAttr(resourceName, "deprovisioning_job_template.0.name", "decommission"),
+					// The deployment is wired to the policy created in the same configuration.
+					resource.TestCheckResourceAttrPair("onefuse_ansible_tower_deployment.deployment", "policy_id", resourceName, "id"),
+					testAccCheckDeploymentPolicyLink("onefuse_ansible_tower_deployment.deployment", AnsibleTowerPolicyResourceType, getAnsibleTowerDeploymentPolicyHref),
+				),
+			},
+			{
+				Config: testAccAnsibleTowerPolicyConfig(ansibleTowerEndpointID, "database"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "provisioning_job_template.0.extra_vars.role", "database"),
+				),
+			},
+			{
+				Config:            testAccAnsibleTowerPolicyConfig(ansibleTowerEndpointID, "database"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccAnsibleTowerPolicyClearedConfig(ansibleTowerEndpointID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "hosts.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "limit", ""),
+					resource.TestCheckResourceAttr(resourceName, "deprovisioning_job_template.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAnsibleTowerPolicyConfig(ansibleTowerEndpointID int, role string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ansible_tower_policy" "policy" {
+  name                      = "tfAccAnsibleTowerPolicy"
+  description               = "Created by the acceptance tests"
+  ansible_tower_endpoint_id = %d
+  inventory                 = "{{ environment }}"
+  hosts                     = ["{{ hostname }}"]
+  limit                     = "{{ hostname }}"
+
+  provisioning_job_template {
+    name = "configure"
+    extra_vars = {
+      role = %q
+    }
+  }
+
+  deprovisioning_job_template {
+    name = "decommission"
+  }
+}
+
+resource "onefuse_ansible_tower_deployment" "deployment" {
+  policy_id = onefuse_ansible_tower_policy.policy.id
+  limit     = "tfacc01"
+}
+`, ansibleTowerEndpointID, role)
+}
+
+func testAccAnsibleTowerPolicyClearedConfig(ansibleTowerEndpointID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ansible_tower_policy" "policy" {
+  name                      = "tfAccAnsibleTowerPolicy"
+  ansible_tower_endpoint_id = %d
+  inventory                 = "{{ environment }}"
+
+  provisioning_job_template {
+    name = "configure"
+  }
+}
+`, ansibleTowerEndpointID)
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceCredential() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCredentialCreate,
+		Read:   resourceCredentialRead,
+		Update: resourceCredentialUpdate,
+		Delete: resourceCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			State: importCredential,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				StateFunc:   hashValue,
+				Description: "The password; OneFuse never returns it and the state only keeps its SHA-256",
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// The password is not bound, as OneFuse never returns it.
+func bindCredentialResource(config *Config, d *schema.ResourceData, credential *Credential) error {
+	log.Println("onefuse.bindCredentialResource")
+
+	if err := d.Set("name", credential.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", credential.Name))
+	}
+
+	if err := d.Set("description", credential.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", credential.Description))
+	}
+
+	if err := d.Set("username", credential.Username); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set username: '%s'", credential.Username))
+	}
+
+	if credential.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, credential.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", credential.Links.Workspace.Href))
+	}
+
+	return nil
+}
+
+// The password is only sent when it is new or rotated; d.Get returns its hash otherwise.
+func credentialFromResource(d *schema.ResourceData) Credential {
+	credential := Credential{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		Username:     d.Get("username").(string),
+		WorkspaceURL: d.Get("workspace_url").(string),
+	}
+	if d.IsNewResource() || d.HasChange("password") {
+		credential.Password = d.Get("password").(string)
+	}
+	return credential
+}
+
+func resourceCredentialCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceCredentialCreate")
+
+	config := m.(Config)
+
+	newCredential := credentialFromResource(d)
+
+	credential, err := config.NewOneFuseApiClient().CreateCredential(&newCredential)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Credential")
+	}
+	d.SetId(strconv.Itoa(credential.ID))
+
+	return resourceCredentialRead(d, m)
+}
+
+func resourceCredentialRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceCredentialRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	credential, err := config.NewOneFuseApiClient().GetCredential(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceCredentialRead: Credential %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Credential")
+	}
+
+	return bindCredentialResource(&config, d, credential)
+}
+
+func resourceCredentialUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceCredentialUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("username") ||
+		d.HasChange("password") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredCredential := credentialFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateCredential(intID, &desiredCredential)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Credential")
+	}
+
+	return resourceCredentialRead(d, m)
+}
+
+func resourceCredentialDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceCredentialDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Credential")
+	}
+
+	return config.NewOneFuseApiClient().DeleteCredential(intID)
+}
+
+// Imports a Credential by ID or by name. The password is not imported, so the next
+// apply sets the password from the configuration.
+func importCredential(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Println("onefuse.importCredential")
+
+	config := meta.(Config)
+
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	credential, err := config.NewOneFuseApiClient().GetCredentialByName(d.Id())
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to import Credential "+d.Id())
+	}
+	d.SetId(strconv.Itoa(credential.ID))
+
+	return []*schema.ResourceData{d}, nil
+}
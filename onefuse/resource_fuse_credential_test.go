@@ -0,0 +1,197 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pkg/errors"
+)
+
+func TestResourceCredentialCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	newCredential := Credential{
+		Name:        "tfCredentialCRUD",
+		Description: "Created by the API client tests",
+		Username:    "svc_onefuse",
+		Password:    "Secret01!",
+	}
+
+	credential, err := apiClient.CreateCredential(&newCredential)
+	if err != nil {
+		t.Fatalf("Error creating Credential: '%s'", err)
+	}
+
+	credential, err = apiClient.GetCredential(credential.ID)
+	if err != nil {
+		t.Fatalf("Error getting Credential: '%s'", err)
+	}
+	if credential.Password != "" {
+		t.Error("Expected OneFuse to not return the Credential's password")
+	}
+
+	credential, err = apiClient.GetCredentialByName(newCredential.Name)
+	if err != nil {
+		t.Fatalf("Error getting Credential by name: '%s'", err)
+	}
+
+	credential.Username = "svc_onefuse02"
+	updatedCredential, err := apiClient.UpdateCredential(credential.ID, credential)
+	if err != nil {
+		t.Fatalf("Error updating Credential: '%s'", err)
+	}
+	if updatedCredential.Username != credential.Username {
+		t.Errorf("Bad username for updated Credential; expected '%s' but got '%s'", credential.Username, updatedCredential.Username)
+	}
+
+	// Without a description, the description is unset
+	credential.Description = ""
+	updatedCredential, err = apiClient.UpdateCredential(credential.ID, credential)
+	if err != nil {
+		t.Fatalf("Error updating Credential: '%s'", err)
+	}
+	if updatedCredential.Description != "" {
+		t.Errorf("Expected updated Credential to have no description but got '%s'", updatedCredential.Description)
+	}
+
+	if err = apiClient.DeleteCredential(credential.ID); err != nil {
+		t.Fatalf("Error deleting Credential: '%s'", err)
+	}
+	if _, err = apiClient.GetCredential(credential.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Credential to be not found but got '%v'", err)
+	}
+}
+
+// Checks the password the fake OneFuse server was given. Live appliances never return it.
+func testAccCheckCredentialPassword(name string, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if fakeServer == nil {
+			return nil
+		}
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return errors.New(fmt.Sprintf("Not found: %s", name))
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		credential := fakeServer.Get(CredentialResourceType, id)
+		if credential == nil {
+			return errors.New(fmt.Sprintf("Credential %d not found", id))
+		}
+		if credential["password"] != password {
+			return errors.New(fmt.Sprintf("Expected OneFuse to have the password from the configuration for Credential %d", id))
+		}
+		return nil
+	}
+}
+
+func TestAccResourceCredential(t *testing.T) {
+	resourceName := "onefuse_credential.credential"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_credential", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetCredential(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_infoblox_endpoint", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetEndpoint(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCredentialConfig("svc_onefuse", "Secret01!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetCredential(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccCredential"),
+					resource.TestCheckResourceAttr(resourceName, "username", "svc_onefuse"),
+					resource.TestCheckResourceAttr(resourceName, "password", hashValue("Secret01!")),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+					testAccCheckCredentialPassword(resourceName, "Secret01!"),
+					resource.TestCheckResourceAttrPair("onefuse_infoblox_endpoint.endpoint", "credential_id", resourceName, "id"),
+				),
+			},
+			{
+				// Rotating the password.
+				Config: testAccCredentialConfig("svc_onefuse", "Secret02!"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "password", hashValue("Secret02!")),
+					testAccCheckCredentialPassword(resourceName, "Secret02!"),
+				),
+			},
+			{
+				// Changing the username keeps the password.
+				Config: testAccCredentialConfig("svc_onefuse02", "Secret02!"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", "svc_onefuse02"),
+					testAccCheckCredentialPassword(resourceName, "Secret02!"),
+				),
+			},
+			{
+				Config:            testAccCredentialConfig("svc_onefuse02", "Secret02!"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "tfAccCredential",
+				ImportStateVerify: true,
+				// OneFuse never returns the password.
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccCredentialClearedConfig("svc_onefuse02", "Secret02!"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					testAccCheckCredentialPassword(resourceName, "Secret02!"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCredentialConfig(username string, password string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_credential" "credential" {
+  name        = "tfAccCredential"
+  description = "Created by the acceptance tests"
+  username    = %q
+  password    = %q
+}
+
+resource "onefuse_infoblox_endpoint" "endpoint" {
+  name          = "tfAccCredentialEndpoint"
+  host          = "infoblox.example.com"
+  credential_id = onefuse_credential.credential.id
+}
+`, username, password)
+}
+
+func testAccCredentialClearedConfig(username string, password string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_credential" "credential" {
+  name     = "tfAccCredential"
+  username = %q
+  password = %q
+}
+`, username, password)
+}
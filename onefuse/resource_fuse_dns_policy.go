@@ -0,0 +1,251 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceDNSPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSPolicyCreate,
+		Read:   resourceDNSPolicyRead,
+		Update: resourceDNSPolicyUpdate,
+		Delete: resourceDNSPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dns_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"zones": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required:    true,
+				Description: "Templates for the zones records are created in",
+			},
+			"record": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The record type, such as a, ptr or cname",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Template for the record name",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Template for the record value",
+						},
+					},
+				},
+			},
+			"ttl": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindDNSPolicyResource(config *Config, d *schema.ResourceData, policy *DNSPolicy) error {
+	log.Println("onefuse.bindDNSPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("zones", policy.Zones); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set zones: %#v", policy.Zones))
+	}
+
+	var records []map[string]interface{}
+	for _, record := range policy.Records {
+		records = append(records, map[string]interface{}{
+			"type":  record.Type,
+			"name":  record.Name,
+			"value": record.Value,
+		})
+	}
+	if err := d.Set("record", records); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set records: %#v", policy.Records))
+	}
+
+	if err := d.Set("ttl", policy.TTL); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set ttl: %d", policy.TTL))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointURLSplit := strings.Split(policy.Links.Endpoint.Href, "/")
+	endpointID := endpointURLSplit[len(endpointURLSplit)-2]
+	endpointIDInt, err := strconv.Atoi(endpointID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Expected to convert '%s' to int value.", endpointID))
+	}
+	if err := d.Set("dns_endpoint_id", endpointIDInt); err != nil {
+		return errors.WithMessage(err, "Cannot set dns_endpoint_id")
+	}
+
+	return nil
+}
+
+func dnsPolicyFromResource(d *schema.ResourceData) DNSPolicy {
+	var zones []string
+	for _, zone := range d.Get("zones").([]interface{}) {
+		zones = append(zones, zone.(string))
+	}
+
+	var records []DNSPolicyRecord
+	for _, record := range d.Get("record").([]interface{}) {
+		recordMap := record.(map[string]interface{})
+		records = append(records, DNSPolicyRecord{
+			Type:  recordMap["type"].(string),
+			Name:  recordMap["name"].(string),
+			Value: recordMap["value"].(string),
+		})
+	}
+
+	return DNSPolicy{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		EndpointID:   d.Get("dns_endpoint_id").(int),
+		Zones:        zones,
+		Records:      records,
+		TTL:          d.Get("ttl").(int),
+		WorkspaceURL: d.Get("workspace_url").(string),
+	}
+}
+
+func resourceDNSPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceDNSPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := dnsPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateDNSPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create DNS Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceDNSPolicyRead(d, m)
+}
+
+func resourceDNSPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceDNSPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetDNSPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceDNSPolicyRead: DNS Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read DNS Policy")
+	}
+
+	return bindDNSPolicyResource(&config, d, policy)
+}
+
+func resourceDNSPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceDNSPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("dns_endpoint_id") ||
+		d.HasChange("zones") ||
+		d.HasChange("record") ||
+		d.HasChange("ttl") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := dnsPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateDNSPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update DNS Policy")
+	}
+
+	return resourceDNSPolicyRead(d, m)
+}
+
+func resourceDNSPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceDNSPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete DNS Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteDNSPolicy(intID)
+}
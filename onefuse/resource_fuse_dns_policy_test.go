@@ -0,0 +1,161 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceDNSPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	dnsEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_DNS_ENDPOINT_ID", "1"))
+	newPolicy := DNSPolicy{
+		Name:        "tfDNSPolicyCRUD",
+		Description: "Created by the API client tests",
+		EndpointID:  dnsEndpointID,
+		Zones:       []string{"example.com"},
+		Records:     []DNSPolicyRecord{{Type: "a", Name: "{{ hostname }}", Value: "{{ ipAddress }}"}},
+		TTL:         300,
+	}
+
+	policy, err := apiClient.CreateDNSPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating DNS Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetDNSPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting DNS Policy: '%s'", err)
+	}
+	if len(policy.Records) != 1 || policy.Records[0] != newPolicy.Records[0] {
+		t.Errorf("Bad records for DNS Policy; expected %v but got %v", newPolicy.Records, policy.Records)
+	}
+	if policy.TTL != newPolicy.TTL {
+		t.Errorf("Bad TTL for DNS Policy; expected %d but got %d", newPolicy.TTL, policy.TTL)
+	}
+
+	policy.Zones = []string{"example.com", "example.org"}
+	updatedPolicy, err := apiClient.UpdateDNSPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating DNS Policy: '%s'", err)
+	}
+	if !testSliceEq(updatedPolicy.Zones, policy.Zones) {
+		t.Errorf("Bad zones for updated DNS Policy; expected %v but got %v", policy.Zones, updatedPolicy.Zones)
+	}
+
+	if err = apiClient.DeleteDNSPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting DNS Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetDNSPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted DNS Policy to be not found but got '%v'", err)
+	}
+}
+
+func TestAccResourceDNSPolicy(t *testing.T) {
+	resourceName := "onefuse_dns_policy.policy"
+	dnsEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_DNS_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_dns_policy", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetDNSPolicy(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSPolicyConfig(dnsEndpointID, 300, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetDNSPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccDNSPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "dns_endpoint_id", strconv.Itoa(dnsEndpointID)),
+					resource.TestCheckResourceAttr(resourceName, "zones.0", "{{ environment }}.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "record.0.type", "a"),
+					resource.TestCheckResourceAttr(resourceName, "record.0.name", "{{ hostname }}"),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "300"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config: testAccDNSPolicyConfig(dnsEndpointID, 600, `
+  record {
+    type  = "ptr"
+    name  = "{{ ipAddress }}"
+    value = "{{ hostname }}"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ttl", "600"),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "record.1.type", "ptr"),
+				),
+			},
+			{
+				Config: testAccDNSPolicyConfig(dnsEndpointID, 600, `
+  record {
+    type  = "ptr"
+    name  = "{{ ipAddress }}"
+    value = "{{ hostname }}"
+  }`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccDNSPolicyClearedConfig(dnsEndpointID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDNSPolicyConfig(dnsEndpointID int, ttl int, extraRecords string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_dns_policy" "policy" {
+  name            = "tfAccDNSPolicy"
+  description     = "Created by the acceptance tests"
+  dns_endpoint_id = %d
+  zones           = ["{{ environment }}.example.com"]
+  ttl             = %d
+
+  record {
+    type  = "a"
+    name  = "{{ hostname }}"
+    value = "{{ ipAddress }}"
+  }%s
+}
+`, dnsEndpointID, ttl, extraRecords)
+}
+
+func testAccDNSPolicyClearedConfig(dnsEndpointID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_dns_policy" "policy" {
+  name            = "tfAccDNSPolicy"
+  dns_endpoint_id = %d
+  zones           = ["{{ environment }}.example.com"]
+
+  record {
+    type  = "a"
+    name  = "{{ hostname }}"
+    value = "{{ ipAddress }}"
+  }
+}
+`, dnsEndpointID)
+}
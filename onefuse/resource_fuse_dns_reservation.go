@@ -82,14 +82,14 @@ func resourceDNSReservation() *schema.Resource {
 	}
 }
 
-func bindDNSReservationResource(d *schema.ResourceData, dnsRecord *DNSReservation) error {
+func bindDNSReservationResource(config *Config, d *schema.ResourceData, dnsRecord *DNSReservation) error {
 	log.Println("onefuse.bindDNSReservationResource")
 
 	if err := d.Set("name", dnsRecord.Name); err != nil {
 		return errors.WithMessage(err, "Cannot set name: "+dnsRecord.Name)
 	}
 
-	if err := d.Set("workspace_url", dnsRecord.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, dnsRecord.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+dnsRecord.Links.Workspace.Href)
 	}
 
@@ -122,13 +122,13 @@ func resourceDNSReservationCreate(d *schema.ResourceData, m interface{}) error {
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	dnsRecord, err := config.NewOneFuseApiClient().CreateDNSReservation(&newDNSRecord)
+	dnsRecord, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateDNSReservation(&newDNSRecord)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(dnsRecord.ID))
 
-	return bindDNSReservationResource(d, dnsRecord)
+	return bindDNSReservationResource(&config, d, dnsRecord)
 }
 
 func resourceDNSReservationRead(d *schema.ResourceData, m interface{}) error {
@@ -143,11 +143,16 @@ func resourceDNSReservationRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	dnsRecord, err := config.NewOneFuseApiClient().GetDNSReservation(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceDNSReservationRead: DNS Reservation %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindDNSReservationResource(d, dnsRecord)
+	return bindDNSReservationResource(&config, d, dnsRecord)
 }
 
 func resourceDNSReservationUpdate(d *schema.ResourceData, m interface{}) error {
@@ -189,12 +194,12 @@ func resourceDNSReservationUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	dnsRecord, err := config.NewOneFuseApiClient().UpdateDNSReservation(intID, &desiredDNSRecord)
+	dnsRecord, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateDNSReservation(intID, &desiredDNSRecord)
 	if err != nil {
 		return err
 	}
 
-	return bindDNSReservationResource(d, dnsRecord)
+	return bindDNSReservationResource(&config, d, dnsRecord)
 }
 
 func resourceDNSReservationDelete(d *schema.ResourceData, m interface{}) error {
@@ -208,7 +213,7 @@ func resourceDNSReservationDelete(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteDNSReservation(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteDNSReservation(intID)
 }
 
 func importDNSReservation(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -233,7 +238,7 @@ func importDNSReservation(d *schema.ResourceData, meta interface{}) ([]*schema.R
     }
 
     // Bind the DNS reservationn
-    if err := bindDNSReservationResource(d, dnsRecord); err != nil {
+    if err := bindDNSReservationResource(&config, d, dnsRecord); err != nil {
         log.Printf("Error binding IPAM reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind IPAM reservation data")
     }
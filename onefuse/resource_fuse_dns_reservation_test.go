@@ -0,0 +1,69 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccResourceDNSReservation(t *testing.T) {
+	resourceName := "onefuse_dns_record.record"
+	dnsPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_DNS_POLICY_ID", "1"))
+	dnsZone := getEnv("CB_ONEFUSE_CFG_DNS_ZONE", "example.com")
+
+	// Updates not yet supported for DNS Reservations.
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_dns_record", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetDNSReservation(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSReservationConfig(dnsPolicyID, dnsZone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetDNSReservation(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacchost"),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(dnsPolicyID)),
+					resource.TestCheckResourceAttr(resourceName, "value", "10.0.0.5"),
+					resource.TestCheckResourceAttr(resourceName, "zones.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config:            testAccDNSReservationConfig(dnsPolicyID, dnsZone),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccDNSReservationConfig(dnsPolicyID int, dnsZone string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_dns_record" "record" {
+  name      = "tfacchost"
+  policy_id = %d
+  value     = "10.0.0.5"
+  zones     = [%q]
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, dnsPolicyID, dnsZone)
+}
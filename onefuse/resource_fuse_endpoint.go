@@ -0,0 +1,219 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+// Returns the resource for endpoints of endpointType, such as InfobloxEndpointType.
+// label names the endpoint in errors, e.g. "Infoblox Endpoint".
+func resourceEndpoint(endpointType string, label string) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceEndpointCreate(d, m, endpointType, label)
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceEndpointRead(d, m, endpointType, label)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceEndpointUpdate(d, m, endpointType, label)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return resourceEndpointDelete(d, m, label)
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"credential_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindEndpointResource(config *Config, d *schema.ResourceData, endpoint *Endpoint) error {
+	log.Println("onefuse.bindEndpointResource")
+
+	if err := d.Set("name", endpoint.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", endpoint.Name))
+	}
+
+	if err := d.Set("description", endpoint.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", endpoint.Description))
+	}
+
+	if err := d.Set("host", endpoint.Host); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set host: '%s'", endpoint.Host))
+	}
+
+	if err := d.Set("port", endpoint.Port); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set port: %d", endpoint.Port))
+	}
+
+	if err := d.Set("ssl", endpoint.SSL); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set ssl: %t", endpoint.SSL))
+	}
+
+	if endpoint.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, endpoint.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", endpoint.Links.Workspace.Href))
+	}
+
+	credentialID, err := endpoint.Links.Credential.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("credential_id", credentialID); err != nil {
+		return errors.WithMessage(err, "Cannot set credential_id")
+	}
+
+	return nil
+}
+
+func endpointFromResource(d *schema.ResourceData, endpointType string) Endpoint {
+	return Endpoint{
+		Type:         endpointType,
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		Host:         d.Get("host").(string),
+		Port:         d.Get("port").(int),
+		SSL:          d.Get("ssl").(bool),
+		CredentialID: d.Get("credential_id").(int),
+		WorkspaceURL: d.Get("workspace_url").(string),
+	}
+}
+
+func resourceEndpointCreate(d *schema.ResourceData, m interface{}, endpointType string, label string) error {
+	log.Println("onefuse.resourceEndpointCreate")
+
+	config := m.(Config)
+
+	newEndpoint := endpointFromResource(d, endpointType)
+
+	endpoint, err := config.NewOneFuseApiClient().CreateEndpoint(&newEndpoint)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create "+label)
+	}
+	d.SetId(strconv.Itoa(endpoint.ID))
+
+	return resourceEndpointRead(d, m, endpointType, label)
+}
+
+func resourceEndpointRead(d *schema.ResourceData, m interface{}, endpointType string, label string) error {
+	log.Println("onefuse.resourceEndpointRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	endpoint, err := config.NewOneFuseApiClient().GetEndpoint(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceEndpointRead: %s %d no longer exists, removing it from state", label, intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read "+label)
+	}
+
+	// All endpoints share one collection, so an import can name an endpoint of another type.
+	if endpoint.Type != endpointType {
+		return errors.New(fmt.Sprintf("Cannot read %s: endpoint %d is of type '%s', not '%s'", label, intID, endpoint.Type, endpointType))
+	}
+
+	return bindEndpointResource(&config, d, endpoint)
+}
+
+func resourceEndpointUpdate(d *schema.ResourceData, m interface{}, endpointType string, label string) error {
+	log.Println("onefuse.resourceEndpointUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("host") ||
+		d.HasChange("port") ||
+		d.HasChange("ssl") ||
+		d.HasChange("credential_id") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredEndpoint := endpointFromResource(d, endpointType)
+
+	_, err = config.NewOneFuseApiClient().UpdateEndpoint(intID, &desiredEndpoint)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update "+label)
+	}
+
+	return resourceEndpointRead(d, m, endpointType, label)
+}
+
+func resourceEndpointDelete(d *schema.ResourceData, m interface{}, label string) error {
+	log.Println("onefuse.resourceEndpointDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete "+label)
+	}
+
+	return config.NewOneFuseApiClient().DeleteEndpoint(intID)
+}
@@ -0,0 +1,252 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/cloudboltsoftware/terraform-provider-onefuse/onefuse/onefusetest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// The endpoint resources built by resourceEndpoint.
+var endpointResources = []struct {
+	resourceType string
+	endpointType string
+}{
+	{"onefuse_infoblox_endpoint", InfobloxEndpointType},
+	{"onefuse_bluecat_endpoint", BluecatEndpointType},
+	{"onefuse_menandmice_endpoint", MenAndMiceEndpointType},
+	{"onefuse_ansible_tower_endpoint", AnsibleTowerEndpointType},
+	{"onefuse_vra_endpoint", VraEndpointType},
+	{"onefuse_servicenow_endpoint", ServicenowEndpointType},
+	{"onefuse_module_endpoint", ModuleEndpointType},
+}
+
+func TestResourceEndpointCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	credentialID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_CREDENTIAL_ID", "1"))
+	newEndpoint := Endpoint{
+		Type:         InfobloxEndpointType,
+		Name:         "tfEndpointCRUD",
+		Description:  "Created by the API client tests",
+		Host:         "infoblox01.example.com",
+		Port:         443,
+		SSL:          true,
+		CredentialID: credentialID,
+	}
+
+	endpoint, err := apiClient.CreateEndpoint(&newEndpoint)
+	if err != nil {
+		t.Fatalf("Error creating Endpoint: '%s'", err)
+	}
+
+	endpoint, err = apiClient.GetEndpoint(endpoint.ID)
+	if err != nil {
+		t.Fatalf("Error getting Endpoint: '%s'", err)
+	}
+	if endpoint.Type != InfobloxEndpointType {
+		t.Errorf("Bad type for Endpoint; expected '%s' but got '%s'", InfobloxEndpointType, endpoint.Type)
+	}
+
+	endpoint.SSL = false
+	updatedEndpoint, err := apiClient.UpdateEndpoint(endpoint.ID, endpoint)
+	if err != nil {
+		t.Fatalf("Error updating Endpoint: '%s'", err)
+	}
+	if updatedEndpoint.SSL {
+		t.Error("Expected updated Endpoint to not use SSL")
+	}
+
+	// Without a description and credential, both are unset
+	endpoint.Description = ""
+	endpoint.Credential = nil
+	updatedEndpoint, err = apiClient.UpdateEndpoint(endpoint.ID, endpoint)
+	if err != nil {
+		t.Fatalf("Error updating Endpoint: '%s'", err)
+	}
+	if updatedEndpoint.Description != "" || updatedEndpoint.Links.Credential.Href != "" {
+		t.Errorf("Expected updated Endpoint to have no description and credential but got '%s' and '%s'", updatedEndpoint.Description, updatedEndpoint.Links.Credential.Href)
+	}
+
+	if err = apiClient.DeleteEndpoint(endpoint.ID); err != nil {
+		t.Fatalf("Error deleting Endpoint: '%s'", err)
+	}
+	if _, err = apiClient.GetEndpoint(endpoint.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Endpoint to be not found but got '%v'", err)
+	}
+}
+
+func TestCreateEndpointRequiresType(t *testing.T) {
+	config := GetConfig()
+
+	_, err := config.NewOneFuseApiClient().CreateEndpoint(&Endpoint{Name: "tfEndpointNoType", Host: "example.com"})
+	if err == nil {
+		t.Fatal("Expected an error creating an Endpoint without a type")
+	}
+}
+
+// Requires an Infoblox Endpoint named "myInfobloxEndpoint"
+func TestGetEndpointByName(t *testing.T) {
+	tables := []struct {
+		name         string
+		endpointType string
+		result       bool
+	}{
+		{getEnv("CB_ONEFUSE_CFG_INFOBLOX_ENDPOINT_NAME", "myInfobloxEndpoint"), InfobloxEndpointType, true},
+		{getEnv("CB_ONEFUSE_CFG_INFOBLOX_ENDPOINT_NAME", "myInfobloxEndpoint"), BluecatEndpointType, false},
+		{"idontexist", InfobloxEndpointType, false},
+	}
+	config := GetConfig()
+
+	for _, table := range tables {
+		_, err := config.NewOneFuseApiClient().GetEndpointByName(table.name, table.endpointType)
+		if table.result == true && err != nil {
+			t.Errorf("Error getting expected %s endpoint by name '%s'", table.endpointType, table.name)
+		} else if table.result == false && err == nil {
+			t.Errorf("Missing error getting nonexistent %s endpoint by name '%s'", table.endpointType, table.name)
+		}
+	}
+}
+
+func TestGetEndpointByNameMatchesTypeExactly(t *testing.T) {
+	server := onefusetest.NewServer()
+	defer server.Close()
+	server.Add(ModuleEndpointResourceType, map[string]interface{}{"name": "sharedName", "type": VraEndpointType})
+	server.Add(ModuleEndpointResourceType, map[string]interface{}{"name": "sharedName", "type": VraEndpointType + "8"})
+
+	config := fakeServerConfig(server)
+
+	endpoint, err := config.NewOneFuseApiClient().GetEndpointByName("sharedName", VraEndpointType)
+	if err != nil {
+		t.Fatalf("Error getting %s endpoint by name: '%s'", VraEndpointType, err)
+	}
+	if endpoint.ID != 1 {
+		t.Errorf("Expected %s endpoint 1 but got %d", VraEndpointType, endpoint.ID)
+	}
+}
+
+func TestAccResourceEndpoints(t *testing.T) {
+	for _, table := range endpointResources {
+		resourceType := table.resourceType
+		resourceName := resourceType + ".endpoint"
+
+		t.Run(resourceType, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				CheckDestroy: testAccCheckDestroyed(resourceType, func(apiClient *OneFuseAPIClient, id int) error {
+					_, err := apiClient.GetEndpoint(id)
+					return err
+				}),
+				Steps: []resource.TestStep{
+					{
+						Config: testAccEndpointConfig(resourceType, "endpoint01.example.com"),
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+								_, err := apiClient.GetEndpoint(id)
+								return err
+							}),
+							resource.TestCheckResourceAttr(resourceName, "name", "tfAccEndpoint"),
+							resource.TestCheckResourceAttr(resourceName, "host", "endpoint01.example.com"),
+							resource.TestCheckResourceAttr(resourceName, "port", "8443"),
+							resource.TestCheckResourceAttr(resourceName, "ssl", "true"),
+							resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+						),
+					},
+					{
+						Config: testAccEndpointConfig(resourceType, "endpoint02.example.com"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "host", "endpoint02.example.com"),
+						),
+					},
+					{
+						Config:            testAccEndpointConfig(resourceType, "endpoint02.example.com"),
+						ResourceName:      resourceName,
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+					{
+						// Optional attributes removed from the configuration are cleared in OneFuse
+						Config: testAccEndpointClearedConfig(resourceType),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "description", ""),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceEndpointImportOtherType(t *testing.T) {
+	microsoftEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_MICROSOFT_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccEndpointConfig("onefuse_infoblox_endpoint", "infoblox.example.com"),
+				ResourceName:  "onefuse_infoblox_endpoint.endpoint",
+				ImportState:   true,
+				ImportStateId: strconv.Itoa(microsoftEndpointID),
+				ExpectError:   regexp.MustCompile("is of type 'microsoft', not 'infoblox'"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEndpoint(t *testing.T) {
+	dataSourceName := "data.onefuse_infoblox_endpoint.endpoint"
+	name := getEnv("CB_ONEFUSE_CFG_INFOBLOX_ENDPOINT_NAME", "myInfobloxEndpoint")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+data "onefuse_infoblox_endpoint" "endpoint" {
+  name = %q
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+					resource.TestCheckResourceAttrSet(dataSourceName, "host"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEndpointConfig(resourceType string, host string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource %q "endpoint" {
+  name        = "tfAccEndpoint"
+  description = "Created by the acceptance tests"
+  host        = %q
+  port        = 8443
+}
+`, resourceType, host)
+}
+
+func testAccEndpointClearedConfig(resourceType string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource %q "endpoint" {
+  name = "tfAccEndpoint"
+  host = "endpoint02.example.com"
+  port = 8443
+}
+`, resourceType)
+}
@@ -0,0 +1,275 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceIPAMPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIPAMPolicyCreate,
+		Read:   resourceIPAMPolicyRead,
+		Update: resourceIPAMPolicyUpdate,
+		Delete: resourceIPAMPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ipam_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"networks": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required:    true,
+				Description: "Templates selecting the networks to reserve addresses from, in order of preference",
+			},
+			"hostname_override": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dns_suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dns_search_suffixes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"gateway": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"primary_dns": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"secondary_dns": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"nic_label": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"update_conflict_name_with_dns": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What OneFuse does when the hostname already has a DNS record",
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindIPAMPolicyResource(config *Config, d *schema.ResourceData, policy *IPAMPolicy) error {
+	log.Println("onefuse.bindIPAMPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("networks", policy.Networks); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set networks: %#v", policy.Networks))
+	}
+
+	if err := d.Set("hostname_override", policy.HostnameOverride); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set hostname_override: '%s'", policy.HostnameOverride))
+	}
+
+	if err := d.Set("dns_suffix", policy.DnsSuffix); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set dns_suffix: '%s'", policy.DnsSuffix))
+	}
+
+	if err := d.Set("dns_search_suffixes", policy.DnsSearchSuffixes); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set dns_search_suffixes: '%s'", policy.DnsSearchSuffixes))
+	}
+
+	if err := d.Set("gateway", policy.Gateway); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set gateway: '%s'", policy.Gateway))
+	}
+
+	if err := d.Set("primary_dns", policy.PrimaryDns); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set primary_dns: '%s'", policy.PrimaryDns))
+	}
+
+	if err := d.Set("secondary_dns", policy.SecondaryDns); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set secondary_dns: '%s'", policy.SecondaryDns))
+	}
+
+	if err := d.Set("nic_label", policy.NicLabel); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set nic_label: '%s'", policy.NicLabel))
+	}
+
+	if err := d.Set("update_conflict_name_with_dns", policy.UpdateConflictNameWithDns); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set update_conflict_name_with_dns: '%s'", policy.UpdateConflictNameWithDns))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointURLSplit := strings.Split(policy.Links.Endpoint.Href, "/")
+	endpointID := endpointURLSplit[len(endpointURLSplit)-2]
+	endpointIDInt, err := strconv.Atoi(endpointID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Expected to convert '%s' to int value.", endpointID))
+	}
+	if err := d.Set("ipam_endpoint_id", endpointIDInt); err != nil {
+		return errors.WithMessage(err, "Cannot set ipam_endpoint_id")
+	}
+
+	return nil
+}
+
+func ipamPolicyFromResource(d *schema.ResourceData) IPAMPolicy {
+	var networks []string
+	for _, network := range d.Get("networks").([]interface{}) {
+		networks = append(networks, network.(string))
+	}
+
+	return IPAMPolicy{
+		Name:                      d.Get("name").(string),
+		Description:               d.Get("description").(string),
+		EndpointID:                d.Get("ipam_endpoint_id").(int),
+		Networks:                  networks,
+		HostnameOverride:          d.Get("hostname_override").(string),
+		DnsSuffix:                 d.Get("dns_suffix").(string),
+		DnsSearchSuffixes:         d.Get("dns_search_suffixes").(string),
+		Gateway:                   d.Get("gateway").(string),
+		PrimaryDns:                d.Get("primary_dns").(string),
+		SecondaryDns:              d.Get("secondary_dns").(string),
+		NicLabel:                  d.Get("nic_label").(string),
+		UpdateConflictNameWithDns: d.Get("update_conflict_name_with_dns").(string),
+		WorkspaceURL:              d.Get("workspace_url").(string),
+	}
+}
+
+func resourceIPAMPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceIPAMPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := ipamPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateIPAMPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create IPAM Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceIPAMPolicyRead(d, m)
+}
+
+func resourceIPAMPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceIPAMPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetIPAMPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceIPAMPolicyRead: IPAM Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read IPAM Policy")
+	}
+
+	return bindIPAMPolicyResource(&config, d, policy)
+}
+
+func resourceIPAMPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceIPAMPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("ipam_endpoint_id") ||
+		d.HasChange("networks") ||
+		d.HasChange("hostname_override") ||
+		d.HasChange("dns_suffix") ||
+		d.HasChange("dns_search_suffixes") ||
+		d.HasChange("gateway") ||
+		d.HasChange("primary_dns") ||
+		d.HasChange("secondary_dns") ||
+		d.HasChange("nic_label") ||
+		d.HasChange("update_conflict_name_with_dns") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := ipamPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateIPAMPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update IPAM Policy")
+	}
+
+	return resourceIPAMPolicyRead(d, m)
+}
+
+func resourceIPAMPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceIPAMPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete IPAM Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteIPAMPolicy(intID)
+}
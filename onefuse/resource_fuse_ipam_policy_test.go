@@ -0,0 +1,152 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceIPAMPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	ipamEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_IPAM_ENDPOINT_ID", "1"))
+	newPolicy := IPAMPolicy{
+		Name:             "tfIPAMPolicyCRUD",
+		Description:      "Created by the API client tests",
+		EndpointID:       ipamEndpointID,
+		Networks:         []string{"10.0.0.0/24"},
+		HostnameOverride: "{{ hostname }}",
+		Gateway:          "10.0.0.1",
+	}
+
+	policy, err := apiClient.CreateIPAMPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating IPAM Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetIPAMPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting IPAM Policy: '%s'", err)
+	}
+	if !testSliceEq(policy.Networks, newPolicy.Networks) {
+		t.Errorf("Bad networks for IPAM Policy; expected %v but got %v", newPolicy.Networks, policy.Networks)
+	}
+	if policy.Links == nil || policy.Links.Endpoint.Href == "" {
+		t.Errorf("Expected IPAM Policy to link to endpoint %d", ipamEndpointID)
+	}
+
+	policy.Networks = []string{"10.0.0.0/24", "10.0.1.0/24"}
+	updatedPolicy, err := apiClient.UpdateIPAMPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating IPAM Policy: '%s'", err)
+	}
+	if !testSliceEq(updatedPolicy.Networks, policy.Networks) {
+		t.Errorf("Bad networks for updated IPAM Policy; expected %v but got %v", policy.Networks, updatedPolicy.Networks)
+	}
+
+	if err = apiClient.DeleteIPAMPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting IPAM Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetIPAMPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted IPAM Policy to be not found but got '%v'", err)
+	}
+}
+
+func TestCreateIPAMPolicyRequiresEndpoint(t *testing.T) {
+	config := GetConfig()
+	_, err := config.NewOneFuseApiClient().CreateIPAMPolicy(&IPAMPolicy{Name: "tfIPAMPolicyNoEndpoint"})
+	if err == nil {
+		t.Errorf("Expected an error creating an IPAM Policy without an endpoint")
+	}
+}
+
+func TestAccResourceIPAMPolicy(t *testing.T) {
+	resourceName := "onefuse_ipam_policy.policy"
+	ipamEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_IPAM_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_ipam_policy", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetIPAMPolicy(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPolicyConfig(ipamEndpointID, "10.0.0.1", `"10.0.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetIPAMPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccIPAMPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "ipam_endpoint_id", strconv.Itoa(ipamEndpointID)),
+					resource.TestCheckResourceAttr(resourceName, "networks.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "networks.0", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "hostname_override", "{{ hostname }}"),
+					resource.TestCheckResourceAttr(resourceName, "gateway", "10.0.0.1"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config: testAccIPAMPolicyConfig(ipamEndpointID, "10.0.0.254", `"10.0.0.0/24", "10.0.1.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "gateway", "10.0.0.254"),
+					resource.TestCheckResourceAttr(resourceName, "networks.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "networks.1", "10.0.1.0/24"),
+				),
+			},
+			{
+				Config:            testAccIPAMPolicyConfig(ipamEndpointID, "10.0.0.254", `"10.0.0.0/24", "10.0.1.0/24"`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccIPAMPolicyClearedConfig(ipamEndpointID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "hostname_override", ""),
+					resource.TestCheckResourceAttr(resourceName, "dns_suffix", ""),
+					resource.TestCheckResourceAttr(resourceName, "gateway", ""),
+					resource.TestCheckResourceAttr(resourceName, "primary_dns", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccIPAMPolicyConfig(ipamEndpointID int, gateway string, networks string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ipam_policy" "policy" {
+  name              = "tfAccIPAMPolicy"
+  description       = "Created by the acceptance tests"
+  ipam_endpoint_id  = %d
+  networks          = [%s]
+  hostname_override = "{{ hostname }}"
+  dns_suffix        = "example.com"
+  gateway           = %q
+  primary_dns       = "10.0.0.10"
+}
+`, ipamEndpointID, networks, gateway)
+}
+
+func testAccIPAMPolicyClearedConfig(ipamEndpointID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ipam_policy" "policy" {
+  name             = "tfAccIPAMPolicy"
+  ipam_endpoint_id = %d
+  networks         = ["10.0.0.0/24"]
+}
+`, ipamEndpointID)
+}
@@ -116,14 +116,14 @@ func resourceIPAMReservation() *schema.Resource {
 	}
 }
 
-func bindIPAMReservationResource(d *schema.ResourceData, ipamRecord *IPAMReservation) error {
+func bindIPAMReservationResource(config *Config, d *schema.ResourceData, ipamRecord *IPAMReservation) error {
 	log.Println("onefuse.bindIPAMReservationResource")
 
 	if err := d.Set("computed_hostname", ipamRecord.Hostname); err != nil {
 		return errors.WithMessage(err, "Cannot set name: "+ipamRecord.Hostname)
 	}
 
-	if err := d.Set("workspace_url", ipamRecord.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, ipamRecord.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+ipamRecord.Links.Workspace.Href)
 	}
 
@@ -204,13 +204,13 @@ func resourceIPAMReservationCreate(d *schema.ResourceData, m interface{}) error
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	ipamRecord, err := config.NewOneFuseApiClient().CreateIPAMReservation(&newIPAMRecord)
+	ipamRecord, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateIPAMReservation(&newIPAMRecord)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(ipamRecord.ID))
 
-	return bindIPAMReservationResource(d, ipamRecord)
+	return bindIPAMReservationResource(&config, d, ipamRecord)
 }
 
 func resourceIPAMReservationRead(d *schema.ResourceData, m interface{}) error {
@@ -225,11 +225,16 @@ func resourceIPAMReservationRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	ipamRecord, err := config.NewOneFuseApiClient().GetIPAMReservation(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceIPAMReservationRead: IPAM Reservation %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindIPAMReservationResource(d, ipamRecord)
+	return bindIPAMReservationResource(&config, d, ipamRecord)
 }
 
 func resourceIPAMReservationUpdate(d *schema.ResourceData, m interface{}) error {
@@ -287,12 +292,12 @@ func resourceIPAMReservationUpdate(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
-	ipamRecord, err := config.NewOneFuseApiClient().UpdateIPAMReservation(intID, &desiredIPAMRecord)
+	ipamRecord, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateIPAMReservation(intID, &desiredIPAMRecord)
 	if err != nil {
 		return err
 	}
 
-	return bindIPAMReservationResource(d, ipamRecord)
+	return bindIPAMReservationResource(&config, d, ipamRecord)
 }
 
 func resourceIPAMReservationDelete(d *schema.ResourceData, m interface{}) error {
@@ -306,7 +311,7 @@ func resourceIPAMReservationDelete(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteIPAMReservation(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteIPAMReservation(intID)
 }
 
 func importIPAMReservation(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -331,7 +336,7 @@ func importIPAMReservation(d *schema.ResourceData, meta interface{}) ([]*schema.
     }
 
     // Bind the IPAM reservation
-    if err := bindIPAMReservationResource(d, ipamRecord); err != nil {
+    if err := bindIPAMReservationResource(&config, d, ipamRecord); err != nil {
         log.Printf("Error binding IPAM reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind IPAM reservation data")
     }
@@ -0,0 +1,82 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccResourceIPAMReservation(t *testing.T) {
+	resourceName := "onefuse_ipam_record.record"
+	ipamPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_IPAM_POLICY_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_ipam_record", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetIPAMReservation(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMReservationConfig(ipamPolicyID, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetIPAMReservation(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "hostname", "tfacchost"),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(ipamPolicyID)),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+					resource.TestCheckResourceAttrSet(resourceName, "netmask"),
+					resource.TestCheckResourceAttrSet(resourceName, "gateway"),
+				),
+			},
+			{
+				// Asking for a specific IP Address replaces the IPAM Reservation.
+				Config: testAccIPAMReservationConfig(ipamPolicyID, "10.0.0.200"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetIPAMReservation(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "ip_address", "10.0.0.200"),
+				),
+			},
+			{
+				Config:            testAccIPAMReservationConfig(ipamPolicyID, "10.0.0.200"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccIPAMReservationConfig(ipamPolicyID int, ipAddress string) string {
+	ipAddressConfig := ""
+	if ipAddress != "" {
+		ipAddressConfig = fmt.Sprintf("ip_address = %q", ipAddress)
+	}
+
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_ipam_record" "record" {
+  hostname  = "tfacchost"
+  policy_id = %d
+  %s
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, ipamPolicyID, ipAddressConfig)
+}
@@ -68,7 +68,7 @@ func resourceMicrosoftADComputerAccount() *schema.Resource {
 	}
 }
 
-func bindMicrosoftADComputerAccountResource(d *schema.ResourceData, computerAccount *MicrosoftADComputerAccount) error {
+func bindMicrosoftADComputerAccountResource(config *Config, d *schema.ResourceData, computerAccount *MicrosoftADComputerAccount) error {
 	log.Println("onefuse.bindMicrosoftADComputerAccountResource")
 
 	if err := d.Set("name", computerAccount.Name); err != nil {
@@ -78,7 +78,7 @@ func bindMicrosoftADComputerAccountResource(d *schema.ResourceData, computerAcco
 		return errors.WithMessage(err, "Cannot set final OU: "+computerAccount.FinalOU)
 	}
 
-	if err := d.Set("workspace_url", computerAccount.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, computerAccount.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+computerAccount.Links.Workspace.Href)
 	}
 
@@ -105,13 +105,13 @@ func resourceMicrosoftADComputerAccountCreate(d *schema.ResourceData, m interfac
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	computerAccount, err := config.NewOneFuseApiClient().CreateMicrosoftADComputerAccount(&newComputerAccount)
+	computerAccount, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateMicrosoftADComputerAccount(&newComputerAccount)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(computerAccount.ID))
 
-	return bindMicrosoftADComputerAccountResource(d, computerAccount)
+	return bindMicrosoftADComputerAccountResource(&config, d, computerAccount)
 }
 
 func resourceMicrosoftADComputerAccountRead(d *schema.ResourceData, m interface{}) error {
@@ -126,11 +126,16 @@ func resourceMicrosoftADComputerAccountRead(d *schema.ResourceData, m interface{
 	}
 
 	computerAccount, err := config.NewOneFuseApiClient().GetMicrosoftADComputerAccount(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceMicrosoftADComputerAccountRead: Microsoft AD Computer Account %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindMicrosoftADComputerAccountResource(d, computerAccount)
+	return bindMicrosoftADComputerAccountResource(&config, d, computerAccount)
 }
 
 func resourceMicrosoftADComputerAccountUpdate(d *schema.ResourceData, m interface{}) error {
@@ -164,12 +169,12 @@ func resourceMicrosoftADComputerAccountUpdate(d *schema.ResourceData, m interfac
 		return err
 	}
 
-	computerAccount, err := config.NewOneFuseApiClient().UpdateMicrosoftADComputerAccount(intID, &desiredComputerAccount)
+	computerAccount, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateMicrosoftADComputerAccount(intID, &desiredComputerAccount)
 	if err != nil {
 		return err
 	}
 
-	return bindMicrosoftADComputerAccountResource(d, computerAccount)
+	return bindMicrosoftADComputerAccountResource(&config, d, computerAccount)
 }
 
 func resourceMicrosoftADComputerAccountDelete(d *schema.ResourceData, m interface{}) error {
@@ -183,7 +188,7 @@ func resourceMicrosoftADComputerAccountDelete(d *schema.ResourceData, m interfac
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteMicrosoftADComputerAccount(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteMicrosoftADComputerAccount(intID)
 }
 
 func importADReservation(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -208,7 +213,7 @@ func importADReservation(d *schema.ResourceData, meta interface{}) ([]*schema.Re
     }
 
     // Bind the AD reservation
-    if err := bindMicrosoftADComputerAccountResource(d, adRecord); err != nil {
+    if err := bindMicrosoftADComputerAccountResource(&config, d, adRecord); err != nil {
         log.Printf("Error binding AD reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind AD reservation data")
     }
@@ -7,9 +7,11 @@
 package onefuse
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -108,3 +110,63 @@ func TestResourceMicrosoftADComputerAccountDelete(t *testing.T) {
 		return
 	}
 }
+
+func TestAccResourceMicrosoftADComputerAccount(t *testing.T) {
+	resourceName := "onefuse_microsoft_ad_computer_account.computer"
+	adPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_MICROSOFT_AD_POLICY_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_microsoft_ad_computer_account", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetMicrosoftADComputerAccount(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMicrosoftADComputerAccountConfig(adPolicyID, "tfacccomputer01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetMicrosoftADComputerAccount(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacccomputer01"),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(adPolicyID)),
+					resource.TestCheckResourceAttrSet(resourceName, "final_ou"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				// Renaming replaces the Computer Account.
+				Config: testAccMicrosoftADComputerAccountConfig(adPolicyID, "tfacccomputer02"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetMicrosoftADComputerAccount(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacccomputer02"),
+				),
+			},
+			{
+				Config:            testAccMicrosoftADComputerAccountConfig(adPolicyID, "tfacccomputer02"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccMicrosoftADComputerAccountConfig(adPolicyID int, name string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_microsoft_ad_computer_account" "computer" {
+  name      = %q
+  policy_id = %d
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, name, adPolicyID)
+}
@@ -23,6 +23,9 @@ func resourceMicrosoftADPolicy() *schema.Resource {
 		Read:   resourceMicrosoftADPolicyRead,
 		Update: resourceMicrosoftADPolicyUpdate,
 		Delete: resourceMicrosoftADPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -75,7 +78,7 @@ func resourceMicrosoftADPolicy() *schema.Resource {
 	}
 }
 
-func bindMicrosoftADPolicyResource(d *schema.ResourceData, policy *MicrosoftADPolicy) error {
+func bindMicrosoftADPolicyResource(config *Config, d *schema.ResourceData, policy *MicrosoftADPolicy) error {
 	log.Println("onefuse.bindMicrosoftADPolicyResource")
 
 	if err := d.Set("name", policy.Name); err != nil {
@@ -86,7 +89,7 @@ func bindMicrosoftADPolicyResource(d *schema.ResourceData, policy *MicrosoftADPo
 		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
 	}
 
-	if err := d.Set("workspace_url", policy.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
 	}
 
@@ -166,11 +169,16 @@ func resourceMicrosoftADPolicyRead(d *schema.ResourceData, m interface{}) error
 	}
 
 	policy, err := config.NewOneFuseApiClient().GetMicrosoftADPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceMicrosoftADPolicyRead: Microsoft AD Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return errors.WithMessage(err, "Failed to read Microsoft AD Policy")
 	}
 
-	return bindMicrosoftADPolicyResource(d, policy)
+	return bindMicrosoftADPolicyResource(&config, d, policy)
 }
 
 func resourceMicrosoftADPolicyUpdate(d *schema.ResourceData, m interface{}) error {
@@ -7,9 +7,11 @@
 package onefuse
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -197,3 +199,64 @@ func testSliceEq(a []string, b []string) bool {
 
 	return true
 }
+
+func TestAccResourceMicrosoftADPolicy(t *testing.T) {
+	resourceName := "onefuse_microsoft_ad_policy.policy"
+	endpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_MICROSOFT_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_microsoft_ad_policy", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetMicrosoftADPolicy(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMicrosoftADPolicyConfig(endpointID, "Description", "OU=Foo,DC=Bar", "UPPER"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetMicrosoftADPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccMicrosoftADPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Description"),
+					resource.TestCheckResourceAttr(resourceName, "ou", "OU=Foo,DC=Bar"),
+					resource.TestCheckResourceAttr(resourceName, "computer_name_letter_case", "UPPER"),
+					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "microsoft_endpoint_id", strconv.Itoa(endpointID)),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config: testAccMicrosoftADPolicyConfig(endpointID, "I am a changed policy", "OU=Updated,DC=Bar", "LOWER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "I am a changed policy"),
+					resource.TestCheckResourceAttr(resourceName, "ou", "OU=Updated,DC=Bar"),
+					resource.TestCheckResourceAttr(resourceName, "computer_name_letter_case", "LOWER"),
+				),
+			},
+			{
+				Config:            testAccMicrosoftADPolicyConfig(endpointID, "I am a changed policy", "OU=Updated,DC=Bar", "LOWER"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMicrosoftADPolicyConfig(endpointID int, description string, ou string, letterCase string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_microsoft_ad_policy" "policy" {
+  name                      = "tfAccMicrosoftADPolicy"
+  description               = %q
+  microsoft_endpoint_id     = %d
+  computer_name_letter_case = %q
+  ou                        = %q
+  create_ou                 = true
+  remove_ou                 = true
+  security_groups           = ["CN=Group,OU=Groups,DC=Bar"]
+}
+`, description, endpointID, letterCase, ou)
+}
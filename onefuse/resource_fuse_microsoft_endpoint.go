@@ -0,0 +1,215 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceMicrosoftEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMicrosoftEndpointCreate,
+		Read:   resourceMicrosoftEndpointRead,
+		Update: resourceMicrosoftEndpointUpdate,
+		Delete: resourceMicrosoftEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"microsoft_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The version of Microsoft Active Directory and DNS on the endpoint",
+			},
+			"credential_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindMicrosoftEndpointResource(config *Config, d *schema.ResourceData, endpoint *MicrosoftEndpoint) error {
+	log.Println("onefuse.bindMicrosoftEndpointResource")
+
+	if err := d.Set("name", endpoint.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", endpoint.Name))
+	}
+
+	if err := d.Set("description", endpoint.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", endpoint.Description))
+	}
+
+	if err := d.Set("host", endpoint.Host); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set host: '%s'", endpoint.Host))
+	}
+
+	if err := d.Set("port", endpoint.Port); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set port: %d", endpoint.Port))
+	}
+
+	if err := d.Set("ssl", endpoint.SSL); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set ssl: %t", endpoint.SSL))
+	}
+
+	if err := d.Set("microsoft_version", endpoint.MicrosoftVersion); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set microsoft version: '%s'", endpoint.MicrosoftVersion))
+	}
+
+	if endpoint.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, endpoint.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", endpoint.Links.Workspace.Href))
+	}
+
+	credentialID, err := endpoint.Links.Credential.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("credential_id", credentialID); err != nil {
+		return errors.WithMessage(err, "Cannot set credential_id")
+	}
+
+	return nil
+}
+
+func microsoftEndpointFromResource(d *schema.ResourceData) MicrosoftEndpoint {
+	return MicrosoftEndpoint{
+		Name:             d.Get("name").(string),
+		Description:      d.Get("description").(string),
+		Host:             d.Get("host").(string),
+		Port:             d.Get("port").(int),
+		SSL:              d.Get("ssl").(bool),
+		MicrosoftVersion: d.Get("microsoft_version").(string),
+		CredentialID:     d.Get("credential_id").(int),
+		WorkspaceURL:     d.Get("workspace_url").(string),
+	}
+}
+
+func resourceMicrosoftEndpointCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceMicrosoftEndpointCreate")
+
+	config := m.(Config)
+
+	newEndpoint := microsoftEndpointFromResource(d)
+
+	endpoint, err := config.NewOneFuseApiClient().CreateMicrosoftEndpoint(newEndpoint)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Microsoft Endpoint")
+	}
+	d.SetId(strconv.Itoa(endpoint.ID))
+
+	return resourceMicrosoftEndpointRead(d, m)
+}
+
+func resourceMicrosoftEndpointRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceMicrosoftEndpointRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	endpoint, err := config.NewOneFuseApiClient().GetMicrosoftEndpoint(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceMicrosoftEndpointRead: Microsoft Endpoint %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Microsoft Endpoint")
+	}
+
+	return bindMicrosoftEndpointResource(&config, d, endpoint)
+}
+
+func resourceMicrosoftEndpointUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceMicrosoftEndpointUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("host") ||
+		d.HasChange("port") ||
+		d.HasChange("ssl") ||
+		d.HasChange("microsoft_version") ||
+		d.HasChange("credential_id") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredEndpoint := microsoftEndpointFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateMicrosoftEndpoint(intID, desiredEndpoint)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Microsoft Endpoint")
+	}
+
+	return resourceMicrosoftEndpointRead(d, m)
+}
+
+func resourceMicrosoftEndpointDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceMicrosoftEndpointDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Microsoft Endpoint")
+	}
+
+	return config.NewOneFuseApiClient().DeleteMicrosoftEndpoint(intID)
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceMicrosoftEndpointCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	credentialID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_CREDENTIAL_ID", "1"))
+	newEndpoint := MicrosoftEndpoint{
+		Name:             "tfMicrosoftEndpointCRUD",
+		Description:      "Created by the API client tests",
+		Host:             "dc01.example.com",
+		Port:             443,
+		SSL:              true,
+		MicrosoftVersion: "2016",
+		CredentialID:     credentialID,
+	}
+
+	endpoint, err := apiClient.CreateMicrosoftEndpoint(newEndpoint)
+	if err != nil {
+		t.Fatalf("Error creating Microsoft Endpoint: '%s'", err)
+	}
+
+	endpoint, err = apiClient.GetMicrosoftEndpoint(endpoint.ID)
+	if err != nil {
+		t.Fatalf("Error getting Microsoft Endpoint: '%s'", err)
+	}
+	if endpoint.Type != "microsoft" {
+		t.Errorf("Bad type for Microsoft Endpoint; expected 'microsoft' but got '%s'", endpoint.Type)
+	}
+	if endpoint.MicrosoftVersion != newEndpoint.MicrosoftVersion {
+		t.Errorf("Bad version for Microsoft Endpoint; expected '%s' but got '%s'", newEndpoint.MicrosoftVersion, endpoint.MicrosoftVersion)
+	}
+
+	endpoint.Host = "dc02.example.com"
+	updatedEndpoint, err := apiClient.UpdateMicrosoftEndpoint(endpoint.ID, *endpoint)
+	if err != nil {
+		t.Fatalf("Error updating Microsoft Endpoint: '%s'", err)
+	}
+	if updatedEndpoint.Host != endpoint.Host {
+		t.Errorf("Bad host for updated Microsoft Endpoint; expected '%s' but got '%s'", endpoint.Host, updatedEndpoint.Host)
+	}
+
+	// Without a description and credential, both are unset
+	endpoint.Description = ""
+	endpoint.Credential = nil
+	updatedEndpoint, err = apiClient.UpdateMicrosoftEndpoint(endpoint.ID, *endpoint)
+	if err != nil {
+		t.Fatalf("Error updating Microsoft Endpoint: '%s'", err)
+	}
+	if updatedEndpoint.Description != "" || updatedEndpoint.Links.Credential.Href != "" {
+		t.Errorf("Expected updated Microsoft Endpoint to have no description and credential but got '%s' and '%s'", updatedEndpoint.Description, updatedEndpoint.Links.Credential.Href)
+	}
+
+	if err = apiClient.DeleteMicrosoftEndpoint(endpoint.ID); err != nil {
+		t.Fatalf("Error deleting Microsoft Endpoint: '%s'", err)
+	}
+	if _, err = apiClient.GetMicrosoftEndpoint(endpoint.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Microsoft Endpoint to be not found but got '%v'", err)
+	}
+}
+
+func TestAccResourceMicrosoftEndpoint(t *testing.T) {
+	resourceName := "onefuse_microsoft_endpoint.endpoint"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_microsoft_endpoint", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetMicrosoftEndpoint(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_microsoft_ad_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetMicrosoftADPolicy(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMicrosoftEndpointConfig("dc01.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetMicrosoftEndpoint(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccMicrosoftEndpoint"),
+					resource.TestCheckResourceAttr(resourceName, "host", "dc01.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "port", "443"),
+					resource.TestCheckResourceAttr(resourceName, "ssl", "true"),
+					resource.TestCheckResourceAttr(resourceName, "microsoft_version", "2016"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+					// The AD Policy references the endpoint created in the same configuration.
+					resource.TestCheckResourceAttrPair("onefuse_microsoft_ad_policy.policy", "microsoft_endpoint_id", resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccMicrosoftEndpointConfig("dc02.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host", "dc02.example.com"),
+				),
+			},
+			{
+				Config:            testAccMicrosoftEndpointConfig("dc02.example.com"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccMicrosoftEndpointClearedConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccMicrosoftEndpointConfig(host string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_microsoft_endpoint" "endpoint" {
+  name              = "tfAccMicrosoftEndpoint"
+  description       = "Created by the acceptance tests"
+  host              = %q
+  port              = 443
+  microsoft_version = "2016"
+}
+
+resource "onefuse_microsoft_ad_policy" "policy" {
+  name                      = "tfAccMicrosoftEndpointADPolicy"
+  microsoft_endpoint_id     = onefuse_microsoft_endpoint.endpoint.id
+  computer_name_letter_case = "Lowercase"
+  ou                        = "OU=Servers,DC=example,DC=com"
+}
+`, host)
+}
+
+func testAccMicrosoftEndpointClearedConfig() string {
+	return testAccProviderConfig() + `
+resource "onefuse_microsoft_endpoint" "endpoint" {
+  name              = "tfAccMicrosoftEndpoint"
+  host              = "dc02.example.com"
+  port              = 443
+  microsoft_version = "2016"
+}
+`
+}
@@ -58,15 +58,16 @@ func resourceModuleDeployment() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func bindModuleDeploymentResource(d *schema.ResourceData, ModuleDeployment *ModuleDeployment) error {
+func bindModuleDeploymentResource(config *Config, d *schema.ResourceData, ModuleDeployment *ModuleDeployment) error {
 	log.Println("onefuse.bindModuleDeploymentResource")
 
-	if err := d.Set("workspace_url", ModuleDeployment.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, ModuleDeployment.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+ModuleDeployment.Links.Workspace.Href)
 	}
 
@@ -115,13 +116,13 @@ func resourceModuleDeploymentCreate(d *schema.ResourceData, m interface{}) error
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	ModuleDeployment, err := config.NewOneFuseApiClient().CreateModuleDeployment(&newModuleDeployment)
+	ModuleDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateModuleDeployment(&newModuleDeployment)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(ModuleDeployment.ID))
 
-	return bindModuleDeploymentResource(d, ModuleDeployment)
+	return bindModuleDeploymentResource(&config, d, ModuleDeployment)
 }
 
 func resourceModuleDeploymentRead(d *schema.ResourceData, m interface{}) error {
@@ -136,11 +137,16 @@ func resourceModuleDeploymentRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	ModuleDeployment, err := config.NewOneFuseApiClient().GetModuleDeployment(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceModuleDeploymentRead: Module Deployment %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindModuleDeploymentResource(d, ModuleDeployment)
+	return bindModuleDeploymentResource(&config, d, ModuleDeployment)
 }
 
 func resourceModuleDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
@@ -171,12 +177,12 @@ func resourceModuleDeploymentUpdate(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
-	ModuleDeployment, err := config.NewOneFuseApiClient().UpdateModuleDeployment(intID, &desiredModuleDeployment)
+	ModuleDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateModuleDeployment(intID, &desiredModuleDeployment)
 	if err != nil {
 		return err
 	}
 
-	return bindModuleDeploymentResource(d, ModuleDeployment)
+	return bindModuleDeploymentResource(&config, d, ModuleDeployment)
 }
 
 func resourceModuleDeploymentDelete(d *schema.ResourceData, m interface{}) error {
@@ -190,7 +196,7 @@ func resourceModuleDeploymentDelete(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteModuleDeployment(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteModuleDeployment(intID)
 }
 
 func importModuleDeployment(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -215,7 +221,7 @@ func importModuleDeployment(d *schema.ResourceData, meta interface{}) ([]*schema
     }
 
     // Bind the Pluggable Module reservation
-    if err := bindModuleDeploymentResource(d, moduleRecord); err != nil {
+    if err := bindModuleDeploymentResource(&config, d, moduleRecord); err != nil {
         log.Printf("Error binding module reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind module reservation data")
     }
@@ -8,9 +8,11 @@ package onefuse
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -116,3 +118,61 @@ func TestResourceModuleDeploymentDelete(t *testing.T) {
 		return
 	}
 }
+
+func TestAccResourceModuleDeployment(t *testing.T) {
+	resourceName := "onefuse_module_deployment.deployment"
+	modulePolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_MODULE_POLICY_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_module_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetModuleDeployment(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModuleDeploymentConfig(modulePolicyID, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetModuleDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(modulePolicyID)),
+					resource.TestCheckResourceAttr(resourceName, "template_properties.environment", "dev"),
+					resource.TestCheckResourceAttrSet(resourceName, "name"),
+					resource.TestCheckResourceAttrSet(resourceName, "provisioning_job_results"),
+				),
+			},
+			{
+				Config: testAccModuleDeploymentConfig(modulePolicyID, "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetModuleDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "template_properties.environment", "prod"),
+				),
+			},
+			{
+				Config:            testAccModuleDeploymentConfig(modulePolicyID, "prod"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccModuleDeploymentConfig(modulePolicyID int, environment string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_module_deployment" "deployment" {
+  policy_id = %d
+  template_properties = {
+    environment = %q
+  }
+}
+`, modulePolicyID, environment)
+}
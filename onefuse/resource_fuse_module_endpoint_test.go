@@ -0,0 +1,54 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"testing"
+)
+
+func TestResourceModuleEndpointCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	newEndpoint := Endpoint{
+		Type:        ModuleEndpointType,
+		Name:        "tfModuleEndpointCRUD",
+		Description: "Created by the API client tests",
+		Host:        "module.example.com",
+		Port:        443,
+		SSL:         true,
+	}
+
+	endpoint, err := apiClient.CreateEndpoint(&newEndpoint)
+	if err != nil {
+		t.Fatalf("Error creating Module Endpoint: '%s'", err)
+	}
+
+	endpoint, err = apiClient.GetEndpoint(endpoint.ID)
+	if err != nil {
+		t.Fatalf("Error getting Module Endpoint: '%s'", err)
+	}
+	if endpoint.Type != ModuleEndpointType {
+		t.Errorf("Bad type for Module Endpoint; expected '%s' but got '%s'", ModuleEndpointType, endpoint.Type)
+	}
+
+	endpoint.SSL = false
+	updatedEndpoint, err := apiClient.UpdateEndpoint(endpoint.ID, endpoint)
+	if err != nil {
+		t.Fatalf("Error updating Module Endpoint: '%s'", err)
+	}
+	if updatedEndpoint.SSL {
+		t.Error("Expected updated Module Endpoint to not use SSL")
+	}
+
+	if err = apiClient.DeleteEndpoint(endpoint.ID); err != nil {
+		t.Fatalf("Error deleting Module Endpoint: '%s'", err)
+	}
+	if _, err = apiClient.GetEndpoint(endpoint.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Module Endpoint to be not found but got '%v'", err)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceModulePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceModulePolicyCreate,
+		Read:   resourceModulePolicyRead,
+		Update: resourceModulePolicyUpdate,
+		Delete: resourceModulePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"module_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pluggable module the policy runs",
+			},
+			"module_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"credential_ids": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional:    true,
+				Description: "IDs of the credentials the module is given",
+			},
+			"policy_template": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "JSON template the module is run with, e.g. from jsonencode() or file()",
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindModulePolicyResource(config *Config, d *schema.ResourceData, policy *ModulePolicy) error {
+	log.Println("onefuse.bindModulePolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("module_name", policy.ModuleName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set module name: '%s'", policy.ModuleName))
+	}
+
+	if err := d.Set("policy_template", policy.PolicyTemplate); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set policy template: '%s'", policy.PolicyTemplate))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointID, err := policy.Links.Endpoint.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("module_endpoint_id", endpointID); err != nil {
+		return errors.WithMessage(err, "Cannot set module_endpoint_id")
+	}
+
+	var credentialIDs []int
+	for _, credential := range policy.Links.Credentials {
+		credentialID, err := credential.ID()
+		if err != nil {
+			return err
+		}
+		credentialIDs = append(credentialIDs, credentialID)
+	}
+	if err := d.Set("credential_ids", credentialIDs); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set credential_ids: %v", credentialIDs))
+	}
+
+	return nil
+}
+
+func modulePolicyFromResource(d *schema.ResourceData) ModulePolicy {
+	var credentialIDs []int
+	for _, credentialID := range d.Get("credential_ids").([]interface{}) {
+		credentialIDs = append(credentialIDs, credentialID.(int))
+	}
+
+	return ModulePolicy{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		ModuleName:     d.Get("module_name").(string),
+		EndpointID:     d.Get("module_endpoint_id").(int),
+		CredentialIDs:  credentialIDs,
+		PolicyTemplate: d.Get("policy_template").(string),
+		WorkspaceURL:   d.Get("workspace_url").(string),
+	}
+}
+
+func resourceModulePolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceModulePolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := modulePolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateModulePolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Module Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceModulePolicyRead(d, m)
+}
+
+func resourceModulePolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceModulePolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetModulePolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceModulePolicyRead: Module Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Module Policy")
+	}
+
+	return bindModulePolicyResource(&config, d, policy)
+}
+
+func resourceModulePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceModulePolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("module_name") ||
+		d.HasChange("module_endpoint_id") ||
+		d.HasChange("credential_ids") ||
+		d.HasChange("policy_template") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := modulePolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateModulePolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Module Policy")
+	}
+
+	return resourceModulePolicyRead(d, m)
+}
+
+func resourceModulePolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceModulePolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Module Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteModulePolicy(intID)
+}
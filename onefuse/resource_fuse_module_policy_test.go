@@ -0,0 +1,191 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceModulePolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	moduleEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_MODULE_ENDPOINT_ID", "1"))
+	credentialID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_CREDENTIAL_ID", "1"))
+	newPolicy := ModulePolicy{
+		Name:           "tfModulePolicyCRUD",
+		Description:    "Created by the API client tests",
+		ModuleName:     "my_module",
+		EndpointID:     moduleEndpointID,
+		CredentialIDs:  []int{credentialID},
+		PolicyTemplate: `{"hostname": "{{ hostname }}"}`,
+	}
+
+	policy, err := apiClient.CreateModulePolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating Module Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetModulePolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting Module Policy: '%s'", err)
+	}
+	if policy.PolicyTemplate != newPolicy.PolicyTemplate {
+		t.Errorf("Bad policy template for Module Policy; expected '%s' but got '%s'", newPolicy.PolicyTemplate, policy.PolicyTemplate)
+	}
+	if len(policy.Links.Credentials) != 1 {
+		t.Errorf("Expected Module Policy to link one credential but got %v", policy.Links.Credentials)
+	}
+
+	policy.PolicyTemplate = `{"hostname": "{{ hostname }}", "size": "small"}`
+	updatedPolicy, err := apiClient.UpdateModulePolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Module Policy: '%s'", err)
+	}
+	if updatedPolicy.PolicyTemplate != policy.PolicyTemplate {
+		t.Errorf("Bad policy template for updated Module Policy; expected '%s' but got '%s'", policy.PolicyTemplate, updatedPolicy.PolicyTemplate)
+	}
+
+	// Without a description and credentials, both are unset
+	policy.Description = ""
+	policy.Credentials = nil
+	updatedPolicy, err = apiClient.UpdateModulePolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Module Policy: '%s'", err)
+	}
+	if updatedPolicy.Description != "" || len(updatedPolicy.Links.Credentials) != 0 {
+		t.Errorf("Expected updated Module Policy to have no description and credentials but got '%s' and %v", updatedPolicy.Description, updatedPolicy.Links.Credentials)
+	}
+
+	if err = apiClient.DeleteModulePolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting Module Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetModulePolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Module Policy to be not found but got '%v'", err)
+	}
+}
+
+func getModuleDeploymentPolicyHref(apiClient *OneFuseAPIClient, id int) (string, error) {
+	deployment, err := apiClient.GetModuleDeployment(id)
+	if err != nil {
+		return "", err
+	}
+	return deployment.Links.Policy.Href, nil
+}
+
+func TestAccResourceModulePolicy(t *testing.T) {
+	resourceName := "onefuse_module_policy.policy"
+	credentialID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_CREDENTIAL_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_module_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetModulePolicy(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_module_endpoint", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetEndpoint(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_module_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetModuleDeployment(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModulePolicyConfig(credentialID, "small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetModulePolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccModulePolicy"),
+					resource.TestCheckResourceAttr(resourceName, "module_name", "my_module"),
+					resource.TestCheckResourceAttrPair(resourceName, "module_endpoint_id", "onefuse_module_endpoint.endpoint", "id"),
+					resource.TestCheckResourceAttr(resourceName, "credential_ids.0", strconv.Itoa(credentialID)),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_template"),
+					// The deployment is wired to the policy created in the same configuration.
+					resource.TestCheckResourceAttrPair("onefuse_module_deployment.deployment", "policy_id", resourceName, "id"),
+					testAccCheckDeploymentPolicyLink("onefuse_module_deployment.deployment", ModulePolicyResourceType, getModuleDeploymentPolicyHref),
+				),
+			},
+			{
+				Config: testAccModulePolicyConfig(credentialID, "large"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "policy_template", `{"hostname":"{{ hostname }}","size":"large"}`),
+				),
+			},
+			{
+				Config:            testAccModulePolicyConfig(credentialID, "large"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccModulePolicyClearedConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "credential_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModulePolicyConfig(credentialID int, size string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_module_endpoint" "endpoint" {
+  name = "tfAccModulePolicyEndpoint"
+  host = "module.example.com"
+}
+
+resource "onefuse_module_policy" "policy" {
+  name               = "tfAccModulePolicy"
+  description        = "Created by the acceptance tests"
+  module_name        = "my_module"
+  module_endpoint_id = onefuse_module_endpoint.endpoint.id
+  credential_ids     = [%d]
+  policy_template = jsonencode({
+    hostname = "{{ hostname }}"
+    size     = %q
+  })
+}
+
+resource "onefuse_module_deployment" "deployment" {
+  policy_id = onefuse_module_policy.policy.id
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, credentialID, size)
+}
+
+func testAccModulePolicyClearedConfig() string {
+	return testAccProviderConfig() + `
+resource "onefuse_module_endpoint" "endpoint" {
+  name = "tfAccModulePolicyEndpoint"
+  host = "module.example.com"
+}
+
+resource "onefuse_module_policy" "policy" {
+  name               = "tfAccModulePolicy"
+  module_name        = "my_module"
+  module_endpoint_id = onefuse_module_endpoint.endpoint.id
+  policy_template = jsonencode({
+    hostname = "{{ hostname }}"
+  })
+}
+`
+}
@@ -93,7 +93,7 @@ func resourceCustomNameCreate(d *schema.ResourceData, m interface{}) error {
 	workspaceID := d.Get("workspace_id").(string)
 	templateProperties := d.Get("template_properties").(map[string]interface{})
 
-	cn, err := config.NewOneFuseApiClient().GenerateCustomName(namingPolicyID, workspaceID, templateProperties)
+	cn, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).GenerateCustomName(namingPolicyID, workspaceID, templateProperties)
 	if err != nil {
 		return err
 	}
@@ -109,6 +109,11 @@ func resourceCustomNameRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Get("custom_name_id").(int)
 
 	customName, err := config.NewOneFuseApiClient().GetCustomName(id)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceCustomNameRead: Custom Name %d no longer exists, removing it from state", id)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -128,7 +133,7 @@ func resourceCustomNameDelete(d *schema.ResourceData, m interface{}) error {
 
 	id := d.Get("custom_name_id").(int)
 
-	return config.NewOneFuseApiClient().DeleteCustomName(id)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteCustomName(id)
 }
 
 func importNaming(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
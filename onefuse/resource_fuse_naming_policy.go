@@ -0,0 +1,199 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceNamingPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNamingPolicyCreate,
+		Read:   resourceNamingPolicyRead,
+		Update: resourceNamingPolicyUpdate,
+		Delete: resourceNamingPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Template for the hostname, usually referencing a Naming Sequence",
+			},
+			"dns_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template for the DNS suffix",
+			},
+			"naming_sequence_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindNamingPolicyResource(config *Config, d *schema.ResourceData, policy *NamingPolicy) error {
+	log.Println("onefuse.bindNamingPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("name_template", policy.NameTemplate); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name_template: '%s'", policy.NameTemplate))
+	}
+
+	if err := d.Set("dns_suffix", policy.DnsSuffixTemplate); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set dns_suffix: '%s'", policy.DnsSuffixTemplate))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	namingSequenceIDInt := 0
+	if policy.Links.NamingSequence.Href != "" {
+		namingSequenceURLSplit := strings.Split(policy.Links.NamingSequence.Href, "/")
+		namingSequenceID := namingSequenceURLSplit[len(namingSequenceURLSplit)-2]
+		var err error
+		if namingSequenceIDInt, err = strconv.Atoi(namingSequenceID); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("Expected to convert '%s' to int value.", namingSequenceID))
+		}
+	}
+	if err := d.Set("naming_sequence_id", namingSequenceIDInt); err != nil {
+		return errors.WithMessage(err, "Cannot set naming_sequence_id")
+	}
+
+	return nil
+}
+
+func namingPolicyFromResource(d *schema.ResourceData) NamingPolicy {
+	return NamingPolicy{
+		Name:              d.Get("name").(string),
+		Description:       d.Get("description").(string),
+		NameTemplate:      d.Get("name_template").(string),
+		DnsSuffixTemplate: d.Get("dns_suffix").(string),
+		NamingSequenceID:  d.Get("naming_sequence_id").(int),
+		WorkspaceURL:      d.Get("workspace_url").(string),
+	}
+}
+
+func resourceNamingPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceNamingPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := namingPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateNamingPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Naming Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceNamingPolicyRead(d, m)
+}
+
+func resourceNamingPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceNamingPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetNamingPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceNamingPolicyRead: Naming Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Naming Policy")
+	}
+
+	return bindNamingPolicyResource(&config, d, policy)
+}
+
+func resourceNamingPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceNamingPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("name_template") ||
+		d.HasChange("dns_suffix") ||
+		d.HasChange("naming_sequence_id") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := namingPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateNamingPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Naming Policy")
+	}
+
+	return resourceNamingPolicyRead(d, m)
+}
+
+func resourceNamingPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceNamingPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Naming Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteNamingPolicy(intID)
+}
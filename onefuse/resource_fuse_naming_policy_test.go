@@ -0,0 +1,147 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceNamingPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	namingSequenceID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_NAMING_SEQUENCE_ID", "1"))
+	newPolicy := NamingPolicy{
+		Name:              "tfNamingPolicyCRUD",
+		Description:       "Created by the API client tests",
+		NameTemplate:      "web{{ sequence }}",
+		DnsSuffixTemplate: "example.com",
+		NamingSequenceID:  namingSequenceID,
+	}
+
+	policy, err := apiClient.CreateNamingPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating Naming Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetNamingPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting Naming Policy: '%s'", err)
+	}
+	if policy.NameTemplate != newPolicy.NameTemplate {
+		t.Errorf("Bad name template for Naming Policy; expected '%s' but got '%s'", newPolicy.NameTemplate, policy.NameTemplate)
+	}
+	if policy.Links == nil || policy.Links.NamingSequence.Href == "" {
+		t.Errorf("Expected Naming Policy to link to Naming Sequence %d", namingSequenceID)
+	}
+
+	policy.Description = "Updated by the API client tests"
+	updatedPolicy, err := apiClient.UpdateNamingPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Naming Policy: '%s'", err)
+	}
+	if updatedPolicy.Description != policy.Description {
+		t.Errorf("Bad description for updated Naming Policy; expected '%s' but got '%s'", policy.Description, updatedPolicy.Description)
+	}
+
+	// Without a DNS suffix and Naming Sequence, both are unset
+	policy.DnsSuffixTemplate = ""
+	policy.NamingSequence = nil
+	policy.NamingSequenceID = 0
+	updatedPolicy, err = apiClient.UpdateNamingPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Naming Policy: '%s'", err)
+	}
+	if updatedPolicy.DnsSuffixTemplate != "" || updatedPolicy.Links.NamingSequence.Href != "" {
+		t.Errorf("Expected updated Naming Policy to have no DNS suffix and Naming Sequence but got '%s' and '%s'", updatedPolicy.DnsSuffixTemplate, updatedPolicy.Links.NamingSequence.Href)
+	}
+
+	if err = apiClient.DeleteNamingPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting Naming Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetNamingPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Naming Policy to be not found but got '%v'", err)
+	}
+}
+
+func TestAccResourceNamingPolicy(t *testing.T) {
+	resourceName := "onefuse_naming_policy.policy"
+	namingSequenceID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_NAMING_SEQUENCE_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_naming_policy", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetNamingPolicy(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNamingPolicyConfig(namingSequenceID, "Web servers", "web{{ sequence }}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetNamingPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccNamingPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Web servers"),
+					resource.TestCheckResourceAttr(resourceName, "name_template", "web{{ sequence }}"),
+					resource.TestCheckResourceAttr(resourceName, "dns_suffix", "{{ environment }}.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "naming_sequence_id", strconv.Itoa(namingSequenceID)),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config: testAccNamingPolicyConfig(namingSequenceID, "Application servers", "app{{ sequence }}"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Application servers"),
+					resource.TestCheckResourceAttr(resourceName, "name_template", "app{{ sequence }}"),
+				),
+			},
+			{
+				Config:            testAccNamingPolicyConfig(namingSequenceID, "Application servers", "app{{ sequence }}"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccNamingPolicyClearedConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "dns_suffix", ""),
+					resource.TestCheckResourceAttr(resourceName, "naming_sequence_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNamingPolicyConfig(namingSequenceID int, description string, nameTemplate string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_naming_policy" "policy" {
+  name               = "tfAccNamingPolicy"
+  description        = %q
+  name_template      = %q
+  dns_suffix         = "{{ environment }}.example.com"
+  naming_sequence_id = %d
+}
+`, description, nameTemplate, namingSequenceID)
+}
+
+func testAccNamingPolicyClearedConfig() string {
+	return testAccProviderConfig() + `
+resource "onefuse_naming_policy" "policy" {
+  name          = "tfAccNamingPolicy"
+  name_template = "app01"
+}
+`
+}
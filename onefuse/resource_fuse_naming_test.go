@@ -7,8 +7,12 @@
 package onefuse
 
 import (
+	"fmt"
 	"os"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestGenerateCustomName(t *testing.T) {
@@ -56,7 +60,13 @@ func TestGetCustomName(t *testing.T) {
 
 }
 
+// Returns the config for the fake OneFuse server, or for the appliance configured in config.env
+// when CB_ONEFUSE_LIVE is set.
 func GetConfig() Config {
+	if fakeServer != nil {
+		return fakeServerConfig(fakeServer)
+	}
+
 	config := Config{
 		scheme:   getEnv("CB_ONEFUSE_CFG_SCHEME", "https"),
 		address:  getEnv("CB_ONEFUSE_CFG_ADDRESS", "localhost"),
@@ -73,3 +83,65 @@ func getEnv(key string, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func TestAccResourceCustomNaming(t *testing.T) {
+	resourceName := "onefuse_naming.name"
+	namingPolicyID := getEnv("CB_ONEFUSE_CFG_NAMING_POLICY_ID", "1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_naming", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetCustomName(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomNamingConfig(namingPolicyID, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetCustomName(id)
+						return err
+					}),
+					resource.TestCheckResourceAttrSet(resourceName, "custom_name_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "name"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_suffix"),
+					resource.TestCheckResourceAttr(resourceName, "naming_policy_id", namingPolicyID),
+				),
+			},
+			{
+				// Changing the template properties keeps the reserved name.
+				Config: testAccCustomNamingConfig(namingPolicyID, "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetCustomName(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "template_properties.environment", "prod"),
+				),
+			},
+			{
+				Config:       testAccCustomNamingConfig(namingPolicyID, "prod"),
+				ResourceName: resourceName,
+				ImportState:  true,
+				// Custom Names are imported by their numeric ID, not the FQDN Terraform uses.
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.Attributes["custom_name_id"], nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"template_properties", "workspace_id"},
+			},
+		},
+	})
+}
+
+func testAccCustomNamingConfig(namingPolicyID string, environment string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_naming" "name" {
+  naming_policy_id = %q
+  template_properties = {
+    environment = %q
+  }
+}
+`, namingPolicyID, environment)
+}
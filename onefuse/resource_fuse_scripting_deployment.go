@@ -58,10 +58,10 @@ func resourceScriptingDeployment() *schema.Resource {
 	}
 }
 
-func bindScriptingDeploymentResource(d *schema.ResourceData, scriptingDeployment *ScriptingDeployment) error {
+func bindScriptingDeploymentResource(config *Config, d *schema.ResourceData, scriptingDeployment *ScriptingDeployment) error {
 	log.Println("onefuse.bindScriptingDeploymentResource")
 
-	if err := d.Set("workspace_url", scriptingDeployment.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, scriptingDeployment.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+scriptingDeployment.Links.Workspace.Href)
 	}
 
@@ -100,13 +100,13 @@ func resourceScriptingDeploymentCreate(d *schema.ResourceData, m interface{}) er
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	scriptingDeployment, err := config.NewOneFuseApiClient().CreateScriptingDeployment(&newScriptingDeployment)
+	scriptingDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateScriptingDeployment(&newScriptingDeployment)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(scriptingDeployment.ID))
 
-	return bindScriptingDeploymentResource(d, scriptingDeployment)
+	return bindScriptingDeploymentResource(&config, d, scriptingDeployment)
 }
 
 func resourceScriptingDeploymentRead(d *schema.ResourceData, m interface{}) error {
@@ -121,11 +121,16 @@ func resourceScriptingDeploymentRead(d *schema.ResourceData, m interface{}) erro
 	}
 
 	scriptingDeployment, err := config.NewOneFuseApiClient().GetScriptingDeployment(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceScriptingDeploymentRead: Scripting Deployment %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindScriptingDeploymentResource(d, scriptingDeployment)
+	return bindScriptingDeploymentResource(&config, d, scriptingDeployment)
 }
 
 func resourceScriptingDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
@@ -156,12 +161,12 @@ func resourceScriptingDeploymentUpdate(d *schema.ResourceData, m interface{}) er
 		return err
 	}
 
-	scriptingDeployment, err := config.NewOneFuseApiClient().UpdateScriptingDeployment(intID, &desiredScriptingDeployment)
+	scriptingDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateScriptingDeployment(intID, &desiredScriptingDeployment)
 	if err != nil {
 		return err
 	}
 
-	return bindScriptingDeploymentResource(d, scriptingDeployment)
+	return bindScriptingDeploymentResource(&config, d, scriptingDeployment)
 }
 
 func resourceScriptingDeploymentDelete(d *schema.ResourceData, m interface{}) error {
@@ -175,7 +180,7 @@ func resourceScriptingDeploymentDelete(d *schema.ResourceData, m interface{}) er
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteScriptingDeployment(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteScriptingDeployment(intID)
 }
 
 func importScriptingReservation(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -200,7 +205,7 @@ func importScriptingReservation(d *schema.ResourceData, meta interface{}) ([]*sc
 	}
 
 	// Bind the scripting reservation
-	if err := bindScriptingDeploymentResource(d, scriptRecord); err != nil {
+	if err := bindScriptingDeploymentResource(&config, d, scriptRecord); err != nil {
 		log.Printf("Error binding script reservation resource: %v", err)
 		return nil, errors.Wrap(err, "failed to bind script reservation data")
 	}
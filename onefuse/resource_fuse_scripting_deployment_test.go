@@ -8,9 +8,11 @@ package onefuse
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -118,3 +120,53 @@ func TestResourceScriptingDeploymentDelete(t *testing.T) {
 		return
 	}
 }
+
+func getScriptingDeploymentArchived(apiClient *OneFuseAPIClient, id int) (bool, error) {
+	scriptingDeployment, err := apiClient.GetScriptingDeployment(id)
+	if err != nil {
+		return false, err
+	}
+	return scriptingDeployment.Archived, nil
+}
+
+func TestAccResourceScriptingDeployment(t *testing.T) {
+	resourceName := "onefuse_scripting_deployment.deployment"
+	scriptingPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_SCRIPTING_POLICY_ID", "1"))
+
+	// Updates not yet supported for Scripting Deployments.
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDestroyedOrArchived("onefuse_scripting_deployment", getScriptingDeploymentArchived),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScriptingDeploymentConfig(scriptingPolicyID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExistsNotArchived(resourceName, getScriptingDeploymentArchived),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(scriptingPolicyID)),
+					resource.TestCheckResourceAttrSet(resourceName, "provisioning_details"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				Config:            testAccScriptingDeploymentConfig(scriptingPolicyID),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccScriptingDeploymentConfig(scriptingPolicyID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_scripting_deployment" "deployment" {
+  policy_id = %d
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, scriptingPolicyID)
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceScriptingPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScriptingPolicyCreate,
+		Read:   resourceScriptingPolicyRead,
+		Update: resourceScriptingPolicyUpdate,
+		Delete: resourceScriptingPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"endpoint_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"credential_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// Scripts are kept in state as their SHA-256 so that changes show up as a short diff.
+			"provisioning_script": {
+				Type:        schema.TypeString,
+				Required:    true,
+				StateFunc:   hashValue,
+				Description: "The script run when a Scripting Deployment is created; use file() to keep it in its own file",
+			},
+			"deprovisioning_script": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				StateFunc:   hashValue,
+				Description: "The script run when a Scripting Deployment is destroyed",
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Returns the SHA-256 of a value kept in state as a hash, such as a script or a password.
+func hashValue(v interface{}) string {
+	value := v.(string)
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(value)))
+}
+
+func bindScriptingPolicyResource(config *Config, d *schema.ResourceData, policy *ScriptingPolicy) error {
+	log.Println("onefuse.bindScriptingPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("provisioning_script", hashValue(policy.ProvisioningScript)); err != nil {
+		return errors.WithMessage(err, "Cannot set provisioning_script")
+	}
+
+	if err := d.Set("deprovisioning_script", hashValue(policy.DeprovisioningScript)); err != nil {
+		return errors.WithMessage(err, "Cannot set deprovisioning_script")
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointID, err := policy.Links.Endpoint.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("endpoint_id", endpointID); err != nil {
+		return errors.WithMessage(err, "Cannot set endpoint_id")
+	}
+
+	credentialID, err := policy.Links.Credential.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("credential_id", credentialID); err != nil {
+		return errors.WithMessage(err, "Cannot set credential_id")
+	}
+
+	return nil
+}
+
+// Builds the policy from the resource. Scripts are only read when they changed, as state only
+// has their hashes; callers fill in the current scripts otherwise.
+func scriptingPolicyFromResource(d *schema.ResourceData) ScriptingPolicy {
+	policy := ScriptingPolicy{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		EndpointID:   d.Get("endpoint_id").(int),
+		CredentialID: d.Get("credential_id").(int),
+		WorkspaceURL: d.Get("workspace_url").(string),
+	}
+
+	if d.IsNewResource() || d.HasChange("provisioning_script") {
+		policy.ProvisioningScript = d.Get("provisioning_script").(string)
+	}
+	if d.IsNewResource() || d.HasChange("deprovisioning_script") {
+		policy.DeprovisioningScript = d.Get("deprovisioning_script").(string)
+	}
+
+	return policy
+}
+
+func resourceScriptingPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceScriptingPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := scriptingPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateScriptingPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Scripting Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceScriptingPolicyRead(d, m)
+}
+
+func resourceScriptingPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceScriptingPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetScriptingPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceScriptingPolicyRead: Scripting Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Scripting Policy")
+	}
+
+	return bindScriptingPolicyResource(&config, d, policy)
+}
+
+func resourceScriptingPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceScriptingPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("endpoint_id") ||
+		d.HasChange("credential_id") ||
+		d.HasChange("provisioning_script") ||
+		d.HasChange("deprovisioning_script") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+	apiClient := config.NewOneFuseApiClient()
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := scriptingPolicyFromResource(d)
+
+	// Keep the scripts that did not change as they are in OneFuse
+	if !d.HasChange("provisioning_script") || !d.HasChange("deprovisioning_script") {
+		currentPolicy, err := apiClient.GetScriptingPolicy(intID)
+		if err != nil {
+			return errors.WithMessage(err, "Failed to read Scripting Policy scripts")
+		}
+		if !d.HasChange("provisioning_script") {
+			desiredPolicy.ProvisioningScript = currentPolicy.ProvisioningScript
+		}
+		if !d.HasChange("deprovisioning_script") {
+			desiredPolicy.DeprovisioningScript = currentPolicy.DeprovisioningScript
+		}
+	}
+
+	_, err = apiClient.UpdateScriptingPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Scripting Policy")
+	}
+
+	return resourceScriptingPolicyRead(d, m)
+}
+
+func resourceScriptingPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceScriptingPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Scripting Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteScriptingPolicy(intID)
+}
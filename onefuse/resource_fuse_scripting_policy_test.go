@@ -0,0 +1,194 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/pkg/errors"
+)
+
+const testProvisioningScript = "#!/bin/bash\necho provisioning {{ hostname }}\n"
+const testDeprovisioningScript = "#!/bin/bash\necho deprovisioning {{ hostname }}\n"
+
+func TestResourceScriptingPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	newPolicy := ScriptingPolicy{
+		Name:                 "tfScriptingPolicyCRUD",
+		Description:          "Created by the API client tests",
+		EndpointID:           1,
+		ProvisioningScript:   testProvisioningScript,
+		DeprovisioningScript: testDeprovisioningScript,
+	}
+
+	policy, err := apiClient.CreateScriptingPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating Scripting Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetScriptingPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting Scripting Policy: '%s'", err)
+	}
+	if policy.ProvisioningScript != testProvisioningScript {
+		t.Errorf("Bad provisioning script for Scripting Policy; got '%s'", policy.ProvisioningScript)
+	}
+
+	policy.DeprovisioningScript = "#!/bin/bash\necho bye\n"
+	updatedPolicy, err := apiClient.UpdateScriptingPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Scripting Policy: '%s'", err)
+	}
+	if updatedPolicy.DeprovisioningScript != policy.DeprovisioningScript {
+		t.Errorf("Bad deprovisioning script for updated Scripting Policy; got '%s'", updatedPolicy.DeprovisioningScript)
+	}
+
+	// Without an endpoint, the endpoint is unset
+	policy.Endpoint = nil
+	policy.EndpointID = 0
+	updatedPolicy, err = apiClient.UpdateScriptingPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating Scripting Policy: '%s'", err)
+	}
+	if updatedPolicy.Links.Endpoint.Href != "" {
+		t.Errorf("Expected updated Scripting Policy to have no endpoint but got '%s'", updatedPolicy.Links.Endpoint.Href)
+	}
+
+	if err = apiClient.DeleteScriptingPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting Scripting Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetScriptingPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Scripting Policy to be not found but got '%v'", err)
+	}
+}
+
+// Checks the scripts OneFuse has for the policy, which state only has the hashes of.
+func testAccCheckScriptingPolicyScripts(name string, provisioningScript string, deprovisioningScript string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return errors.New(fmt.Sprintf("Not found: %s", name))
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		config := GetConfig()
+		policy, err := config.NewOneFuseApiClient().GetScriptingPolicy(id)
+		if err != nil {
+			return err
+		}
+		if policy.ProvisioningScript != provisioningScript {
+			return errors.New(fmt.Sprintf("Bad provisioning script; expected %q but got %q", provisioningScript, policy.ProvisioningScript))
+		}
+		if policy.DeprovisioningScript != deprovisioningScript {
+			return errors.New(fmt.Sprintf("Bad deprovisioning script; expected %q but got %q", deprovisioningScript, policy.DeprovisioningScript))
+		}
+		return nil
+	}
+}
+
+func TestAccResourceScriptingPolicy(t *testing.T) {
+	resourceName := "onefuse_scripting_policy.policy"
+
+	scriptDir, err := ioutil.TempDir("", "onefuse-scripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(scriptDir)
+
+	scriptFile := filepath.Join(scriptDir, "deprovision.sh")
+	if err := ioutil.WriteFile(scriptFile, []byte(testDeprovisioningScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	updatedProvisioningScript := "#!/bin/bash\necho provisioning {{ hostname }} again\n"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_scripting_policy", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetScriptingPolicy(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScriptingPolicyConfig("Description", testProvisioningScript, scriptFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetScriptingPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccScriptingPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_script", hashValue(testProvisioningScript)),
+					resource.TestCheckResourceAttr(resourceName, "deprovisioning_script", hashValue(testDeprovisioningScript)),
+					testAccCheckScriptingPolicyScripts(resourceName, testProvisioningScript, testDeprovisioningScript),
+				),
+			},
+			{
+				Config: testAccScriptingPolicyConfig("Description", updatedProvisioningScript, scriptFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "provisioning_script", hashValue(updatedProvisioningScript)),
+					testAccCheckScriptingPolicyScripts(resourceName, updatedProvisioningScript, testDeprovisioningScript),
+				),
+			},
+			{
+				// Updating other arguments keeps the scripts.
+				Config: testAccScriptingPolicyConfig("Changed description", updatedProvisioningScript, scriptFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Changed description"),
+					testAccCheckScriptingPolicyScripts(resourceName, updatedProvisioningScript, testDeprovisioningScript),
+				),
+			},
+			{
+				Config:            testAccScriptingPolicyConfig("Changed description", updatedProvisioningScript, scriptFile),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccScriptingPolicyClearedConfig(updatedProvisioningScript),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "deprovisioning_script", ""),
+					testAccCheckScriptingPolicyScripts(resourceName, updatedProvisioningScript, ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccScriptingPolicyConfig(description string, provisioningScript string, deprovisioningScriptFile string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_scripting_policy" "policy" {
+  name                  = "tfAccScriptingPolicy"
+  description           = %q
+  provisioning_script   = %q
+  deprovisioning_script = file(%q)
+}
+`, description, provisioningScript, deprovisioningScriptFile)
+}
+
+func testAccScriptingPolicyClearedConfig(provisioningScript string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_scripting_policy" "policy" {
+  name                = "tfAccScriptingPolicy"
+  provisioning_script = %q
+}
+`, provisioningScript)
+}
@@ -56,15 +56,16 @@ func resourceServicenowCMDBDeployment() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func bindServicenowCMDBDeploymentResource(d *schema.ResourceData, servicenowCMDBDeployment *ServicenowCMDBDeployment) error {
+func bindServicenowCMDBDeploymentResource(config *Config, d *schema.ResourceData, servicenowCMDBDeployment *ServicenowCMDBDeployment) error {
 	log.Println("onefuse.bindServicenowCMDBDeploymentResource")
 
-	if err := d.Set("workspace_url", servicenowCMDBDeployment.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, servicenowCMDBDeployment.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+servicenowCMDBDeployment.Links.Workspace.Href)
 	}
 
@@ -103,13 +104,13 @@ func resourceServicenowCMDBDeploymentCreate(d *schema.ResourceData, m interface{
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	servicenowCMDBDeployment, err := config.NewOneFuseApiClient().CreateServicenowCMDBDeployment(&newServicenowCMDBDeployment)
+	servicenowCMDBDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateServicenowCMDBDeployment(&newServicenowCMDBDeployment)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(servicenowCMDBDeployment.ID))
 
-	return bindServicenowCMDBDeploymentResource(d, servicenowCMDBDeployment)
+	return bindServicenowCMDBDeploymentResource(&config, d, servicenowCMDBDeployment)
 }
 
 func resourceServicenowCMDBDeploymentRead(d *schema.ResourceData, m interface{}) error {
@@ -124,11 +125,16 @@ func resourceServicenowCMDBDeploymentRead(d *schema.ResourceData, m interface{})
 	}
 
 	servicenowCMDBDeployment, err := config.NewOneFuseApiClient().GetServicenowCMDBDeployment(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceServicenowCMDBDeploymentRead: ServiceNow CMDB Deployment %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindServicenowCMDBDeploymentResource(d, servicenowCMDBDeployment)
+	return bindServicenowCMDBDeploymentResource(&config, d, servicenowCMDBDeployment)
 }
 
 func resourceServicenowCMDBDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
@@ -159,12 +165,12 @@ func resourceServicenowCMDBDeploymentUpdate(d *schema.ResourceData, m interface{
 		return err
 	}
 
-	servicenowCMDBDeployment, err := config.NewOneFuseApiClient().UpdateServicenowCMDBDeployment(intID, &desiredServicenowCMDBDeployment)
+	servicenowCMDBDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutUpdate)).UpdateServicenowCMDBDeployment(intID, &desiredServicenowCMDBDeployment)
 	if err != nil {
 		return err
 	}
 
-	return bindServicenowCMDBDeploymentResource(d, servicenowCMDBDeployment)
+	return bindServicenowCMDBDeploymentResource(&config, d, servicenowCMDBDeployment)
 }
 
 func resourceServicenowCMDBDeploymentDelete(d *schema.ResourceData, m interface{}) error {
@@ -178,7 +184,7 @@ func resourceServicenowCMDBDeploymentDelete(d *schema.ResourceData, m interface{
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteServicenowCMDBDeployment(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteServicenowCMDBDeployment(intID)
 }
 
 func importServiceNowCmdbDeployment(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -203,7 +209,7 @@ func importServiceNowCmdbDeployment(d *schema.ResourceData, meta interface{}) ([
     }
 
     // Bind the ServiceNow Cmdb reservation record
-    if err := bindServicenowCMDBDeploymentResource(d, snowRecord); err != nil {
+    if err := bindServicenowCMDBDeploymentResource(&config, d, snowRecord); err != nil {
         log.Printf("Error binding ServiceNow reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind ServiceNow reservation data")
     }
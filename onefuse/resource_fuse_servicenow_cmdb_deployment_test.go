@@ -0,0 +1,72 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccResourceServicenowCMDBDeployment(t *testing.T) {
+	resourceName := "onefuse_servicenow_cmdb_deployment.deployment"
+	servicenowCMDBPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_SERVICENOW_CMDB_POLICY_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_servicenow_cmdb_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetServicenowCMDBDeployment(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicenowCMDBDeploymentConfig(servicenowCMDBPolicyID, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetServicenowCMDBDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(servicenowCMDBPolicyID)),
+					resource.TestCheckResourceAttr(resourceName, "template_properties.environment", "dev"),
+					resource.TestCheckResourceAttrSet(resourceName, "configuration_items_info.#"),
+				),
+			},
+			{
+				Config: testAccServicenowCMDBDeploymentConfig(servicenowCMDBPolicyID, "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetServicenowCMDBDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "template_properties.environment", "prod"),
+				),
+			},
+			{
+				Config:            testAccServicenowCMDBDeploymentConfig(servicenowCMDBPolicyID, "prod"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccServicenowCMDBDeploymentConfig(servicenowCMDBPolicyID int, environment string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_servicenow_cmdb_deployment" "deployment" {
+  policy_id = %d
+  template_properties = {
+    environment = %q
+  }
+}
+`, servicenowCMDBPolicyID, environment)
+}
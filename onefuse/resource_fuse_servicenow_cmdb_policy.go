@@ -0,0 +1,310 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceServicenowCMDBPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServicenowCMDBPolicyCreate,
+		Read:   resourceServicenowCMDBPolicyRead,
+		Update: resourceServicenowCMDBPolicyUpdate,
+		Delete: resourceServicenowCMDBPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"servicenow_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"configuration_item": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Configuration items created in the CMDB",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Template for the CMDB class of the configuration item, e.g. cmdb_ci_server",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Template for the name of the configuration item",
+						},
+						"attributes": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Description: "Templates for the attributes of the configuration item",
+						},
+					},
+				},
+			},
+			"relationship": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Relationships between the configuration items, referenced by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"parent": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"child": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The CMDB relationship type, e.g. Runs on::Runs",
+						},
+					},
+				},
+			},
+			"execution_strategy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "How OneFuse runs the ServiceNow requests, defaults to the OneFuse setting",
+			},
+			"update_conflict_strategy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "What OneFuse does when a configuration item already exists, defaults to the OneFuse setting",
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindServicenowCMDBPolicyResource(config *Config, d *schema.ResourceData, policy *ServicenowCMDBPolicy) error {
+	log.Println("onefuse.bindServicenowCMDBPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("configuration_item", flattenServicenowCMDBConfigurationItems(policy.ConfigurationItems)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set configuration items: %#v", policy.ConfigurationItems))
+	}
+
+	if err := d.Set("relationship", flattenServicenowCMDBRelationships(policy.Relationships)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set relationships: %#v", policy.Relationships))
+	}
+
+	if err := d.Set("execution_strategy", policy.ExecutionStrategy); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set execution strategy: '%s'", policy.ExecutionStrategy))
+	}
+
+	if err := d.Set("update_conflict_strategy", policy.UpdateConflictStrategy); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set update conflict strategy: '%s'", policy.UpdateConflictStrategy))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointID, err := policy.Links.Endpoint.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("servicenow_endpoint_id", endpointID); err != nil {
+		return errors.WithMessage(err, "Cannot set servicenow_endpoint_id")
+	}
+
+	return nil
+}
+
+func flattenServicenowCMDBConfigurationItems(configurationItems []ServicenowCMDBConfigurationItem) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, configurationItem := range configurationItems {
+		flattened = append(flattened, map[string]interface{}{
+			"class_name": configurationItem.ClassName,
+			"name":       configurationItem.Name,
+			"attributes": configurationItem.Attributes,
+		})
+	}
+	return flattened
+}
+
+func expandServicenowCMDBConfigurationItems(configurationItems []interface{}) []ServicenowCMDBConfigurationItem {
+	var expanded []ServicenowCMDBConfigurationItem
+	for _, configurationItem := range configurationItems {
+		configurationItemMap := configurationItem.(map[string]interface{})
+		attributes := make(map[string]string)
+		for name, value := range configurationItemMap["attributes"].(map[string]interface{}) {
+			attributes[name] = value.(string)
+		}
+		expanded = append(expanded, ServicenowCMDBConfigurationItem{
+			ClassName:  configurationItemMap["class_name"].(string),
+			Name:       configurationItemMap["name"].(string),
+			Attributes: attributes,
+		})
+	}
+	return expanded
+}
+
+func flattenServicenowCMDBRelationships(relationships []ServicenowCMDBRelationship) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, relationship := range relationships {
+		flattened = append(flattened, map[string]interface{}{
+			"parent": relationship.Parent,
+			"child":  relationship.Child,
+			"type":   relationship.Type,
+		})
+	}
+	return flattened
+}
+
+func expandServicenowCMDBRelationships(relationships []interface{}) []ServicenowCMDBRelationship {
+	var expanded []ServicenowCMDBRelationship
+	for _, relationship := range relationships {
+		relationshipMap := relationship.(map[string]interface{})
+		expanded = append(expanded, ServicenowCMDBRelationship{
+			Parent: relationshipMap["parent"].(string),
+			Child:  relationshipMap["child"].(string),
+			Type:   relationshipMap["type"].(string),
+		})
+	}
+	return expanded
+}
+
+func servicenowCMDBPolicyFromResource(d *schema.ResourceData) ServicenowCMDBPolicy {
+	return ServicenowCMDBPolicy{
+		Name:                   d.Get("name").(string),
+		Description:            d.Get("description").(string),
+		EndpointID:             d.Get("servicenow_endpoint_id").(int),
+		ConfigurationItems:     expandServicenowCMDBConfigurationItems(d.Get("configuration_item").([]interface{})),
+		Relationships:          expandServicenowCMDBRelationships(d.Get("relationship").([]interface{})),
+		ExecutionStrategy:      d.Get("execution_strategy").(string),
+		UpdateConflictStrategy: d.Get("update_conflict_strategy").(string),
+		WorkspaceURL:           d.Get("workspace_url").(string),
+	}
+}
+
+func resourceServicenowCMDBPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceServicenowCMDBPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := servicenowCMDBPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateServicenowCMDBPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create ServiceNow CMDB Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceServicenowCMDBPolicyRead(d, m)
+}
+
+func resourceServicenowCMDBPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceServicenowCMDBPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetServicenowCMDBPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceServicenowCMDBPolicyRead: ServiceNow CMDB Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read ServiceNow CMDB Policy")
+	}
+
+	return bindServicenowCMDBPolicyResource(&config, d, policy)
+}
+
+func resourceServicenowCMDBPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceServicenowCMDBPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("servicenow_endpoint_id") ||
+		d.HasChange("configuration_item") ||
+		d.HasChange("relationship") ||
+		d.HasChange("execution_strategy") ||
+		d.HasChange("update_conflict_strategy") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := servicenowCMDBPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateServicenowCMDBPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update ServiceNow CMDB Policy")
+	}
+
+	return resourceServicenowCMDBPolicyRead(d, m)
+}
+
+func resourceServicenowCMDBPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceServicenowCMDBPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete ServiceNow CMDB Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteServicenowCMDBPolicy(intID)
+}
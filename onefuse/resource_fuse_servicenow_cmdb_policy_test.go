@@ -0,0 +1,187 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceServicenowCMDBPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	servicenowEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_SERVICENOW_ENDPOINT_ID", "1"))
+	newPolicy := ServicenowCMDBPolicy{
+		Name:        "tfServicenowCMDBPolicyCRUD",
+		Description: "Created by the API client tests",
+		EndpointID:  servicenowEndpointID,
+		ConfigurationItems: []ServicenowCMDBConfigurationItem{
+			{ClassName: "cmdb_ci_server", Name: "{{ hostname }}", Attributes: map[string]string{"ip_address": "{{ ip_address }}"}},
+			{ClassName: "cmdb_ci_appl", Name: "{{ hostname }}_app"},
+		},
+		Relationships: []ServicenowCMDBRelationship{
+			{Parent: "{{ hostname }}_app", Child: "{{ hostname }}", Type: "Runs on::Runs"},
+		},
+	}
+
+	policy, err := apiClient.CreateServicenowCMDBPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating ServiceNow CMDB Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetServicenowCMDBPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting ServiceNow CMDB Policy: '%s'", err)
+	}
+	if len(policy.ConfigurationItems) != 2 || policy.ConfigurationItems[0].Attributes["ip_address"] != "{{ ip_address }}" {
+		t.Errorf("Bad configuration items for ServiceNow CMDB Policy; got %v", policy.ConfigurationItems)
+	}
+	if len(policy.Relationships) != 1 || policy.Relationships[0].Type != "Runs on::Runs" {
+		t.Errorf("Bad relationships for ServiceNow CMDB Policy; got %v", policy.Relationships)
+	}
+
+	policy.Relationships = nil
+	updatedPolicy, err := apiClient.UpdateServicenowCMDBPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating ServiceNow CMDB Policy: '%s'", err)
+	}
+	if len(updatedPolicy.Relationships) != 0 {
+		t.Errorf("Expected updated ServiceNow CMDB Policy to have no relationships but got %v", updatedPolicy.Relationships)
+	}
+
+	if err = apiClient.DeleteServicenowCMDBPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting ServiceNow CMDB Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetServicenowCMDBPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted ServiceNow CMDB Policy to be not found but got '%v'", err)
+	}
+}
+
+func getServicenowCMDBDeploymentPolicyHref(apiClient *OneFuseAPIClient, id int) (string, error) {
+	deployment, err := apiClient.GetServicenowCMDBDeployment(id)
+	if err != nil {
+		return "", err
+	}
+	return deployment.Links.Policy.Href, nil
+}
+
+func TestAccResourceServicenowCMDBPolicy(t *testing.T) {
+	resourceName := "onefuse_servicenow_cmdb_policy.policy"
+	servicenowEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_SERVICENOW_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_servicenow_cmdb_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetServicenowCMDBPolicy(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_servicenow_cmdb_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetServicenowCMDBDeployment(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicenowCMDBPolicyConfig(servicenowEndpointID, "cmdb_ci_linux_server"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetServicenowCMDBPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccServicenowCMDBPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "servicenow_endpoint_id", strconv.Itoa(servicenowEndpointID)),
+					resource.TestCheckResourceAttr(resourceName, "configuration_item.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_item.0.class_name", "cmdb_ci_linux_server"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_item.0.attributes.ip_address", "{{ ip_address }}"),
+					resource.TestCheckResourceAttr(resourceName, "relationship.0.type", "Runs on::Runs"),
+					resource.TestCheckResourceAttr(resourceName, "execution_strategy", "sync"),
+					// The deployment is wired to the policy created in the same configuration.
+					resource.TestCheckResourceAttrPair("onefuse_servicenow_cmdb_deployment.deployment", "policy_id", resourceName, "id"),
+					testAccCheckDeploymentPolicyLink("onefuse_servicenow_cmdb_deployment.deployment", ServicenowCMDBPolicyResourceType, getServicenowCMDBDeploymentPolicyHref),
+				),
+			},
+			{
+				Config: testAccServicenowCMDBPolicyConfig(servicenowEndpointID, "cmdb_ci_win_server"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "configuration_item.0.class_name", "cmdb_ci_win_server"),
+				),
+			},
+			{
+				Config:            testAccServicenowCMDBPolicyConfig(servicenowEndpointID, "cmdb_ci_win_server"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccServicenowCMDBPolicyClearedConfig(servicenowEndpointID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "relationship.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServicenowCMDBPolicyConfig(servicenowEndpointID int, serverClassName string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_servicenow_cmdb_policy" "policy" {
+  name                   = "tfAccServicenowCMDBPolicy"
+  description            = "Created by the acceptance tests"
+  servicenow_endpoint_id = %d
+  execution_strategy     = "sync"
+
+  configuration_item {
+    class_name = %q
+    name       = "{{ hostname }}"
+    attributes = {
+      ip_address = "{{ ip_address }}"
+    }
+  }
+
+  configuration_item {
+    class_name = "cmdb_ci_appl"
+    name       = "{{ hostname }}_app"
+  }
+
+  relationship {
+    parent = "{{ hostname }}_app"
+    child  = "{{ hostname }}"
+    type   = "Runs on::Runs"
+  }
+}
+
+resource "onefuse_servicenow_cmdb_deployment" "deployment" {
+  policy_id = onefuse_servicenow_cmdb_policy.policy.id
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, servicenowEndpointID, serverClassName)
+}
+
+func testAccServicenowCMDBPolicyClearedConfig(servicenowEndpointID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_servicenow_cmdb_policy" "policy" {
+  name                   = "tfAccServicenowCMDBPolicy"
+  servicenow_endpoint_id = %d
+  execution_strategy     = "sync"
+
+  configuration_item {
+    class_name = "cmdb_ci_win_server"
+    name       = "{{ hostname }}"
+  }
+}
+`, servicenowEndpointID)
+}
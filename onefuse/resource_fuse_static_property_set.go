@@ -0,0 +1,193 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceStaticPropertySet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStaticPropertySetCreate,
+		Read:   resourceStaticPropertySetRead,
+		Update: resourceStaticPropertySetUpdate,
+		Delete: resourceStaticPropertySetDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStaticPropertySet,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"properties_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "JSON object of the properties, e.g. from jsonencode()",
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindStaticPropertySetResource(config *Config, d *schema.ResourceData, staticPropertySet *StaticPropertySet) error {
+	log.Println("onefuse.bindStaticPropertySetResource")
+
+	if err := d.Set("name", staticPropertySet.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", staticPropertySet.Name))
+	}
+
+	if err := d.Set("description", staticPropertySet.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", staticPropertySet.Description))
+	}
+
+	propertiesJSON := string(staticPropertySet.PropertiesJSON)
+	if err := d.Set("properties_json", propertiesJSON); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set properties: '%s'", propertiesJSON))
+	}
+
+	if staticPropertySet.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, staticPropertySet.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", staticPropertySet.Links.Workspace.Href))
+	}
+
+	return nil
+}
+
+func staticPropertySetFromResource(d *schema.ResourceData) StaticPropertySet {
+	return StaticPropertySet{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		PropertiesJSON: json.RawMessage(d.Get("properties_json").(string)),
+		WorkspaceURL:   d.Get("workspace_url").(string),
+	}
+}
+
+func resourceStaticPropertySetCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceStaticPropertySetCreate")
+
+	config := m.(Config)
+
+	newStaticPropertySet := staticPropertySetFromResource(d)
+
+	staticPropertySet, err := config.NewOneFuseApiClient().CreateStaticPropertySet(&newStaticPropertySet)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Static Property Set")
+	}
+	d.SetId(strconv.Itoa(staticPropertySet.ID))
+
+	return resourceStaticPropertySetRead(d, m)
+}
+
+func resourceStaticPropertySetRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceStaticPropertySetRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	staticPropertySet, err := config.NewOneFuseApiClient().GetStaticPropertySet(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceStaticPropertySetRead: Static Property Set %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Static Property Set")
+	}
+
+	return bindStaticPropertySetResource(&config, d, staticPropertySet)
+}
+
+func resourceStaticPropertySetUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceStaticPropertySetUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("properties_json") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredStaticPropertySet := staticPropertySetFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateStaticPropertySet(intID, &desiredStaticPropertySet)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Static Property Set")
+	}
+
+	return resourceStaticPropertySetRead(d, m)
+}
+
+func resourceStaticPropertySetDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceStaticPropertySetDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Static Property Set")
+	}
+
+	return config.NewOneFuseApiClient().DeleteStaticPropertySet(intID)
+}
+
+// Imports a Static Property Set by ID or by name.
+func importStaticPropertySet(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Println("onefuse.importStaticPropertySet")
+
+	config := meta.(Config)
+
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	staticPropertySet, err := config.NewOneFuseApiClient().GetStaticPropertySetByName(d.Id())
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to import Static Property Set "+d.Id())
+	}
+	d.SetId(strconv.Itoa(staticPropertySet.ID))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const testStaticPropertySetProperties = `{"OneFuse_ADPolicy": [{"name": "ad_policy", "ous": ["OU=Servers"]}], "environment": "dev", "retries": 3}`
+
+func TestResourceStaticPropertySetCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	newStaticPropertySet := StaticPropertySet{
+		Name:           "tfStaticPropertySetCRUD",
+		Description:    "Created by the API client tests",
+		PropertiesJSON: json.RawMessage(testStaticPropertySetProperties),
+	}
+
+	staticPropertySet, err := apiClient.CreateStaticPropertySet(&newStaticPropertySet)
+	if err != nil {
+		t.Fatalf("Error creating Static Property Set: '%s'", err)
+	}
+
+	staticPropertySet, err = apiClient.GetStaticPropertySet(staticPropertySet.ID)
+	if err != nil {
+		t.Fatalf("Error getting Static Property Set: '%s'", err)
+	}
+
+	var expected map[string]interface{}
+	json.Unmarshal([]byte(testStaticPropertySetProperties), &expected)
+	if !reflect.DeepEqual(staticPropertySet.Properties, expected) {
+		t.Errorf("Bad properties for Static Property Set; expected '%v' but got '%v'", expected, staticPropertySet.Properties)
+	}
+
+	staticPropertySet, err = apiClient.GetStaticPropertySetByName(newStaticPropertySet.Name)
+	if err != nil {
+		t.Fatalf("Error getting Static Property Set by name: '%s'", err)
+	}
+
+	// Properties are sent when PropertiesJSON is not set
+	staticPropertySet.PropertiesJSON = nil
+	staticPropertySet.Properties["environment"] = "prod"
+	updatedStaticPropertySet, err := apiClient.UpdateStaticPropertySet(staticPropertySet.ID, staticPropertySet)
+	if err != nil {
+		t.Fatalf("Error updating Static Property Set: '%s'", err)
+	}
+	if updatedStaticPropertySet.Properties["environment"] != "prod" {
+		t.Errorf("Bad environment for updated Static Property Set; expected 'prod' but got '%v'", updatedStaticPropertySet.Properties["environment"])
+	}
+
+	// Without a description, the description is unset
+	staticPropertySet.Description = ""
+	updatedStaticPropertySet, err = apiClient.UpdateStaticPropertySet(staticPropertySet.ID, staticPropertySet)
+	if err != nil {
+		t.Fatalf("Error updating Static Property Set: '%s'", err)
+	}
+	if updatedStaticPropertySet.Description != "" {
+		t.Errorf("Expected updated Static Property Set to have no description but got '%s'", updatedStaticPropertySet.Description)
+	}
+
+	if err = apiClient.DeleteStaticPropertySet(staticPropertySet.ID); err != nil {
+		t.Fatalf("Error deleting Static Property Set: '%s'", err)
+	}
+	if _, err = apiClient.GetStaticPropertySet(staticPropertySet.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Static Property Set to be not found but got '%v'", err)
+	}
+}
+
+func TestAccResourceStaticPropertySet(t *testing.T) {
+	resourceName := "onefuse_static_property_set.sps"
+	dataSourceName := "data.onefuse_static_property_set.sps"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_static_property_set", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetStaticPropertySet(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStaticPropertySetConfig("dev", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetStaticPropertySet(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccStaticPropertySet"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+				),
+			},
+			{
+				// Imported before the data source is read, which has the same type and ID.
+				Config:            testAccStaticPropertySetConfig("dev", false),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "tfAccStaticPropertySet",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccStaticPropertySetConfig("prod", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "properties_json", `{"OneFuse_ADPolicy":[{"name":"ad_policy","ous":["OU=Servers","OU=Web"]}],"environment":"prod"}`),
+				),
+			},
+			{
+				// Reordering the keys is not a change.
+				Config:   testAccStaticPropertySetReorderedConfig(),
+				PlanOnly: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccProviderConfig() + `
+resource "onefuse_static_property_set" "sps" {
+  name            = "tfAccStaticPropertySet"
+  properties_json = jsonencode({ environment = "prod" })
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccStaticPropertySetConfig(environment string, withDataSource bool) string {
+	config := testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_static_property_set" "sps" {
+  name        = "tfAccStaticPropertySet"
+  description = "Created by the acceptance tests"
+  properties_json = jsonencode({
+    environment = %q
+    OneFuse_ADPolicy = [
+      {
+        name = "ad_policy"
+        ous  = ["OU=Servers", "OU=Web"]
+      }
+    ]
+  })
+}
+`, environment)
+
+	if withDataSource {
+		config += `
+data "onefuse_static_property_set" "sps" {
+  name = onefuse_static_property_set.sps.name
+}
+`
+	}
+	return config
+}
+
+func testAccStaticPropertySetReorderedConfig() string {
+	return testAccProviderConfig() + `
+resource "onefuse_static_property_set" "sps" {
+  name            = "tfAccStaticPropertySet"
+  description     = "Created by the acceptance tests"
+  properties_json = <<EOF
+{
+  "environment": "prod",
+  "OneFuse_ADPolicy": [{"ous": ["OU=Servers", "OU=Web"], "name": "ad_policy"}]
+}
+EOF
+}
+
+data "onefuse_static_property_set" "sps" {
+  name = onefuse_static_property_set.sps.name
+}
+`
+}
@@ -68,10 +68,10 @@ func resourceVraDeployment() *schema.Resource {
 	}
 }
 
-func bindVraDeploymentResource(d *schema.ResourceData, vraDeployment *VraDeployment) error {
+func bindVraDeploymentResource(config *Config, d *schema.ResourceData, vraDeployment *VraDeployment) error {
 	log.Println("onefuse.bindVraDeploymentResource")
 
-	if err := d.Set("workspace_url", vraDeployment.Links.Workspace.Href); err != nil {
+	if err := d.Set("workspace_url", workspaceReference(config, d, vraDeployment.Links.Workspace)); err != nil {
 		return errors.WithMessage(err, "Cannot set workspace: "+vraDeployment.Links.Workspace.Href)
 	}
 
@@ -118,13 +118,13 @@ func resourceVraDeploymentCreate(d *schema.ResourceData, m interface{}) error {
 		TemplateProperties: d.Get("template_properties").(map[string]interface{}),
 	}
 
-	vraDeployment, err := config.NewOneFuseApiClient().CreateVraDeployment(&newVraDeployment)
+	vraDeployment, err := config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutCreate)).CreateVraDeployment(&newVraDeployment)
 	if err != nil {
 		return err
 	}
 	d.SetId(strconv.Itoa(vraDeployment.ID))
 
-	return bindVraDeploymentResource(d, vraDeployment)
+	return bindVraDeploymentResource(&config, d, vraDeployment)
 }
 
 func resourceVraDeploymentRead(d *schema.ResourceData, m interface{}) error {
@@ -139,11 +139,16 @@ func resourceVraDeploymentRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	vraDeployment, err := config.NewOneFuseApiClient().GetVraDeployment(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceVraDeploymentRead: vRA Deployment %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	return bindVraDeploymentResource(d, vraDeployment)
+	return bindVraDeploymentResource(&config, d, vraDeployment)
 }
 
 func resourceVraDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
@@ -163,7 +168,7 @@ func resourceVraDeploymentDelete(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	return config.NewOneFuseApiClient().DeleteVraDeployment(intID)
+	return config.NewOneFuseApiClient().WithTimeout(d.Timeout(schema.TimeoutDelete)).DeleteVraDeployment(intID)
 }
 
 func importVraDeployment(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -188,7 +193,7 @@ func importVraDeployment(d *schema.ResourceData, meta interface{}) ([]*schema.Re
     }
 
     // Bind the IPAM reservation record
-    if err := bindVraDeploymentResource(d, vraRecord); err != nil {
+    if err := bindVraDeploymentResource(&config, d, vraRecord); err != nil {
         log.Printf("Error binding vRA reservation resource: %v", err)
         return nil, errors.Wrap(err, "failed to bind vRA reservation data")
     }
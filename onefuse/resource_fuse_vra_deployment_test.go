@@ -8,9 +8,11 @@ package onefuse
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/pkg/errors"
 )
 
@@ -118,3 +120,62 @@ func TestResourceVraDeploymentDelete(t *testing.T) {
 		return
 	}
 }
+
+func TestAccResourceVraDeployment(t *testing.T) {
+	resourceName := "onefuse_vra_deployment.deployment"
+	vraPolicyID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_VRA_POLICY_ID", "2348"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckDestroyed("onefuse_vra_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+			_, err := apiClient.GetVraDeployment(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVraDeploymentConfig(vraPolicyID, "tfAccVraDeployment01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetVraDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", strconv.Itoa(vraPolicyID)),
+					resource.TestCheckResourceAttr(resourceName, "deployment_name", "tfAccVraDeployment01"),
+					resource.TestCheckResourceAttrSet(resourceName, "deployment_info"),
+				),
+			},
+			{
+				// Renaming replaces the vRealize Automation Deployment.
+				Config: testAccVraDeploymentConfig(vraPolicyID, "tfAccVraDeployment02"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetVraDeployment(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "deployment_name", "tfAccVraDeployment02"),
+				),
+			},
+			{
+				Config:            testAccVraDeploymentConfig(vraPolicyID, "tfAccVraDeployment02"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Template Properties are not read back on import.
+				ImportStateVerifyIgnore: []string{"template_properties"},
+			},
+		},
+	})
+}
+
+func testAccVraDeploymentConfig(vraPolicyID int, deploymentName string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_vra_deployment" "deployment" {
+  policy_id       = %d
+  deployment_name = %q
+  template_properties = {
+    environment = "dev"
+  }
+}
+`, vraPolicyID, deploymentName)
+}
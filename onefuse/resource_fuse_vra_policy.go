@@ -0,0 +1,223 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceVraPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVraPolicyCreate,
+		Read:   resourceVraPolicyRead,
+		Update: resourceVraPolicyUpdate,
+		Delete: resourceVraPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vra_endpoint_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"blueprint_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Template for the name of the vRA blueprint to deploy",
+			},
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Template for the name of the vRA project the blueprint is deployed to",
+			},
+			"deployment_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template for the name of the vRA deployment",
+			},
+			"input_templates": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "Templates for the inputs passed to the blueprint",
+			},
+			"workspace_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindVraPolicyResource(config *Config, d *schema.ResourceData, policy *VraPolicy) error {
+	log.Println("onefuse.bindVraPolicyResource")
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", policy.Name))
+	}
+
+	if err := d.Set("description", policy.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", policy.Description))
+	}
+
+	if err := d.Set("blueprint_name", policy.BlueprintName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set blueprint name: '%s'", policy.BlueprintName))
+	}
+
+	if err := d.Set("project_name", policy.ProjectName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set project name: '%s'", policy.ProjectName))
+	}
+
+	if err := d.Set("deployment_name", policy.DeploymentNameTemplate); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set deployment name: '%s'", policy.DeploymentNameTemplate))
+	}
+
+	if err := d.Set("input_templates", policy.InputTemplates); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set input templates: %#v", policy.InputTemplates))
+	}
+
+	if policy.Links == nil {
+		return nil
+	}
+
+	if err := d.Set("workspace_url", workspaceReference(config, d, policy.Links.Workspace)); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set workspace: '%s'", policy.Links.Workspace.Href))
+	}
+
+	endpointID, err := policy.Links.Endpoint.ID()
+	if err != nil {
+		return err
+	}
+	if err := d.Set("vra_endpoint_id", endpointID); err != nil {
+		return errors.WithMessage(err, "Cannot set vra_endpoint_id")
+	}
+
+	return nil
+}
+
+func vraPolicyFromResource(d *schema.ResourceData) VraPolicy {
+	inputTemplates := make(map[string]string)
+	for name, value := range d.Get("input_templates").(map[string]interface{}) {
+		inputTemplates[name] = value.(string)
+	}
+
+	return VraPolicy{
+		Name:                   d.Get("name").(string),
+		Description:            d.Get("description").(string),
+		EndpointID:             d.Get("vra_endpoint_id").(int),
+		BlueprintName:          d.Get("blueprint_name").(string),
+		ProjectName:            d.Get("project_name").(string),
+		DeploymentNameTemplate: d.Get("deployment_name").(string),
+		InputTemplates:         inputTemplates,
+		WorkspaceURL:           d.Get("workspace_url").(string),
+	}
+}
+
+func resourceVraPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceVraPolicyCreate")
+
+	config := m.(Config)
+
+	newPolicy := vraPolicyFromResource(d)
+
+	policy, err := config.NewOneFuseApiClient().CreateVraPolicy(&newPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create vRA Policy")
+	}
+	d.SetId(strconv.Itoa(policy.ID))
+
+	return resourceVraPolicyRead(d, m)
+}
+
+func resourceVraPolicyRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceVraPolicyRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	policy, err := config.NewOneFuseApiClient().GetVraPolicy(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceVraPolicyRead: vRA Policy %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read vRA Policy")
+	}
+
+	return bindVraPolicyResource(&config, d, policy)
+}
+
+func resourceVraPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceVraPolicyUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description") ||
+		d.HasChange("vra_endpoint_id") ||
+		d.HasChange("blueprint_name") ||
+		d.HasChange("project_name") ||
+		d.HasChange("deployment_name") ||
+		d.HasChange("input_templates") ||
+		d.HasChange("workspace_url"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredPolicy := vraPolicyFromResource(d)
+
+	_, err = config.NewOneFuseApiClient().UpdateVraPolicy(intID, &desiredPolicy)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update vRA Policy")
+	}
+
+	return resourceVraPolicyRead(d, m)
+}
+
+func resourceVraPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceVraPolicyDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete vRA Policy")
+	}
+
+	return config.NewOneFuseApiClient().DeleteVraPolicy(intID)
+}
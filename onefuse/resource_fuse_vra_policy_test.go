@@ -0,0 +1,174 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceVraPolicyCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	vraEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_VRA_ENDPOINT_ID", "1"))
+	newPolicy := VraPolicy{
+		Name:                   "tfVraPolicyCRUD",
+		Description:            "Created by the API client tests",
+		EndpointID:             vraEndpointID,
+		BlueprintName:          "{{ sps_blueprint }}",
+		ProjectName:            "{{ sps_project }}",
+		DeploymentNameTemplate: "{{ hostname }}",
+		InputTemplates:         map[string]string{"size": "small"},
+	}
+
+	policy, err := apiClient.CreateVraPolicy(&newPolicy)
+	if err != nil {
+		t.Fatalf("Error creating vRA Policy: '%s'", err)
+	}
+
+	policy, err = apiClient.GetVraPolicy(policy.ID)
+	if err != nil {
+		t.Fatalf("Error getting vRA Policy: '%s'", err)
+	}
+	if policy.InputTemplates["size"] != "small" {
+		t.Errorf("Bad input templates for vRA Policy; got %v", policy.InputTemplates)
+	}
+
+	policy.ProjectName = "{{ sps_other_project }}"
+	updatedPolicy, err := apiClient.UpdateVraPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating vRA Policy: '%s'", err)
+	}
+	if updatedPolicy.ProjectName != policy.ProjectName {
+		t.Errorf("Bad project name for updated vRA Policy; expected '%s' but got '%s'", policy.ProjectName, updatedPolicy.ProjectName)
+	}
+
+	// Without a description, deployment name and input templates, all are unset
+	policy.Description = ""
+	policy.DeploymentNameTemplate = ""
+	policy.InputTemplates = nil
+	updatedPolicy, err = apiClient.UpdateVraPolicy(policy.ID, policy)
+	if err != nil {
+		t.Fatalf("Error updating vRA Policy: '%s'", err)
+	}
+	if updatedPolicy.Description != "" || updatedPolicy.DeploymentNameTemplate != "" || len(updatedPolicy.InputTemplates) != 0 {
+		t.Errorf("Expected updated vRA Policy to have no description, deployment name or input templates but got '%s', '%s' and %v", updatedPolicy.Description, updatedPolicy.DeploymentNameTemplate, updatedPolicy.InputTemplates)
+	}
+
+	if err = apiClient.DeleteVraPolicy(policy.ID); err != nil {
+		t.Fatalf("Error deleting vRA Policy: '%s'", err)
+	}
+	if _, err = apiClient.GetVraPolicy(policy.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted vRA Policy to be not found but got '%v'", err)
+	}
+}
+
+func TestCreateVraPolicyRequiresEndpoint(t *testing.T) {
+	config := GetConfig()
+
+	_, err := config.NewOneFuseApiClient().CreateVraPolicy(&VraPolicy{Name: "tfVraPolicyNoEndpoint"})
+	if err == nil {
+		t.Fatal("Expected an error creating a vRA Policy without an endpoint")
+	}
+}
+
+func TestAccResourceVraPolicy(t *testing.T) {
+	resourceName := "onefuse_vra_policy.policy"
+	vraEndpointID, _ := strconv.Atoi(getEnv("CB_ONEFUSE_CFG_VRA_ENDPOINT_ID", "1"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_vra_policy", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetVraPolicy(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_vra_deployment", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetVraDeployment(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVraPolicyConfig(vraEndpointID, "small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetVraPolicy(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccVraPolicy"),
+					resource.TestCheckResourceAttr(resourceName, "vra_endpoint_id", strconv.Itoa(vraEndpointID)),
+					resource.TestCheckResourceAttr(resourceName, "blueprint_name", "{{ sps_blueprint }}"),
+					resource.TestCheckResourceAttr(resourceName, "project_name", "{{ sps_project }}"),
+					resource.TestCheckResourceAttr(resourceName, "deployment_name", "{{ hostname }}"),
+					resource.TestCheckResourceAttr(resourceName, "input_templates.size", "small"),
+					resource.TestCheckResourceAttrSet(resourceName, "workspace_url"),
+					// The deployment is wired to the policy created in the same configuration.
+					resource.TestCheckResourceAttrPair("onefuse_vra_deployment.deployment", "policy_id", resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccVraPolicyConfig(vraEndpointID, "large"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "input_templates.size", "large"),
+				),
+			},
+			{
+				Config:            testAccVraPolicyConfig(vraEndpointID, "large"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccVraPolicyClearedConfig(vraEndpointID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "deployment_name", ""),
+					resource.TestCheckResourceAttr(resourceName, "input_templates.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVraPolicyConfig(vraEndpointID int, size string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_vra_policy" "policy" {
+  name            = "tfAccVraPolicy"
+  description     = "Created by the acceptance tests"
+  vra_endpoint_id = %d
+  blueprint_name  = "{{ sps_blueprint }}"
+  project_name    = "{{ sps_project }}"
+  deployment_name = "{{ hostname }}"
+  input_templates = {
+    size = %q
+  }
+}
+
+resource "onefuse_vra_deployment" "deployment" {
+  policy_id       = onefuse_vra_policy.policy.id
+  deployment_name = "tfAccVraPolicyDeployment"
+}
+`, vraEndpointID, size)
+}
+
+func testAccVraPolicyClearedConfig(vraEndpointID int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_vra_policy" "policy" {
+  name            = "tfAccVraPolicy"
+  vra_endpoint_id = %d
+  blueprint_name  = "{{ sps_blueprint }}"
+  project_name    = "{{ sps_project }}"
+}
+`, vraEndpointID)
+}
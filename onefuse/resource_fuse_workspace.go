@@ -0,0 +1,165 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceWorkspace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWorkspaceCreate,
+		Read:   resourceWorkspaceRead,
+		Update: resourceWorkspaceUpdate,
+		Delete: resourceWorkspaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func bindWorkspaceResource(d *schema.ResourceData, workspace *Workspace) error {
+	log.Println("onefuse.bindWorkspaceResource")
+
+	if err := d.Set("name", workspace.Name); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set name: '%s'", workspace.Name))
+	}
+
+	if err := d.Set("description", workspace.Description); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("Cannot set description: '%s'", workspace.Description))
+	}
+
+	return nil
+}
+
+func resourceWorkspaceCreate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceWorkspaceCreate")
+
+	config := m.(Config)
+
+	newWorkspace := Workspace{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	workspace, err := config.NewOneFuseApiClient().CreateWorkspace(&newWorkspace)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to create Workspace")
+	}
+	d.SetId(strconv.Itoa(workspace.ID))
+
+	return resourceWorkspaceRead(d, m)
+}
+
+func resourceWorkspaceRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceWorkspaceRead")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to convert integer ID to string: "+id)
+	}
+
+	workspace, err := config.NewOneFuseApiClient().GetWorkspace(intID)
+	if IsNotFound(err) {
+		log.Printf("onefuse.resourceWorkspaceRead: Workspace %d no longer exists, removing it from state", intID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessage(err, "Failed to read Workspace")
+	}
+
+	return bindWorkspaceResource(d, workspace)
+}
+
+func resourceWorkspaceUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceWorkspaceUpdate")
+
+	// Determine if a change is needed
+	changed := (d.HasChange("name") ||
+		d.HasChange("description"))
+
+	if !changed {
+		return nil
+	}
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	desiredWorkspace := Workspace{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	_, err = config.NewOneFuseApiClient().UpdateWorkspace(intID, &desiredWorkspace)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to update Workspace")
+	}
+
+	return resourceWorkspaceRead(d, m)
+}
+
+func resourceWorkspaceDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("onefuse.resourceWorkspaceDelete")
+
+	config := m.(Config)
+
+	id := d.Id()
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.WithMessage(err, "Failed to delete Workspace")
+	}
+
+	return config.NewOneFuseApiClient().DeleteWorkspace(intID)
+}
+
+// Returns the value to keep in workspace_url for the workspace OneFuse links to.
+// The configured value is kept when it resolves to that workspace, so its ID, name or
+// URL can be given without a diff; otherwise the link's href is stored.
+func workspaceReference(config *Config, d *schema.ResourceData, link LinkRef) string {
+	configured := d.Get("workspace_url").(string)
+	if configured == "" || link.Href == "" {
+		return link.Href
+	}
+
+	linkID, err := link.ID()
+	if err != nil {
+		return link.Href
+	}
+	workspaceURL, err := findWorkspaceURLOrDefault(config, configured)
+	if err != nil {
+		log.Printf("onefuse.workspaceReference: Failed to resolve workspace '%s': %s", configured, err)
+		return link.Href
+	}
+	if configuredID, err := (LinkRef{Href: workspaceURL}).ID(); err == nil && configuredID == linkID {
+		return configured
+	}
+	return link.Href
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudboltsoftware/terraform-provider-onefuse/onefuse/onefusetest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceWorkspaceCRUD(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	newWorkspace := Workspace{
+		Name:        "tfWorkspaceCRUD",
+		Description: "Created by the API client tests",
+	}
+
+	workspace, err := apiClient.CreateWorkspace(&newWorkspace)
+	if err != nil {
+		t.Fatalf("Error creating Workspace: '%s'", err)
+	}
+
+	workspace, err = apiClient.GetWorkspace(workspace.ID)
+	if err != nil {
+		t.Fatalf("Error getting Workspace: '%s'", err)
+	}
+
+	workspace, err = apiClient.GetWorkspaceByName(newWorkspace.Name)
+	if err != nil {
+		t.Fatalf("Error getting Workspace by name: '%s'", err)
+	}
+
+	workspace.Description = "Updated by the API client tests"
+	updatedWorkspace, err := apiClient.UpdateWorkspace(workspace.ID, workspace)
+	if err != nil {
+		t.Fatalf("Error updating Workspace: '%s'", err)
+	}
+	if updatedWorkspace.Description != workspace.Description {
+		t.Errorf("Bad description for updated Workspace; expected '%s' but got '%s'", workspace.Description, updatedWorkspace.Description)
+	}
+
+	// Without a description, the description is unset
+	workspace.Description = ""
+	updatedWorkspace, err = apiClient.UpdateWorkspace(workspace.ID, workspace)
+	if err != nil {
+		t.Fatalf("Error updating Workspace: '%s'", err)
+	}
+	if updatedWorkspace.Description != "" {
+		t.Errorf("Expected updated Workspace to have no description but got '%s'", updatedWorkspace.Description)
+	}
+
+	if err = apiClient.DeleteWorkspace(workspace.ID); err != nil {
+		t.Fatalf("Error deleting Workspace: '%s'", err)
+	}
+	if _, err = apiClient.GetWorkspace(workspace.ID); !IsNotFound(err) {
+		t.Errorf("Expected deleted Workspace to be not found but got '%v'", err)
+	}
+}
+
+func TestFindWorkspaceURLOrDefault(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	workspace, err := apiClient.CreateWorkspace(&Workspace{Name: "tfWorkspaceLookup"})
+	if err != nil {
+		t.Fatalf("Error creating Workspace: '%s'", err)
+	}
+	defer apiClient.DeleteWorkspace(workspace.ID)
+
+	expected := itemURL(&config, WorkspaceResourceType, workspace.ID)
+	for _, workspaceURL := range []string{expected, fmt.Sprint(workspace.ID), workspace.Name} {
+		actual, err := findWorkspaceURLOrDefault(&config, workspaceURL)
+		if err != nil {
+			t.Errorf("Error finding Workspace '%s': '%s'", workspaceURL, err)
+		}
+		if actual != expected {
+			t.Errorf("Bad URL for Workspace '%s'; expected '%s' but got '%s'", workspaceURL, expected, actual)
+		}
+	}
+
+	if _, err := findWorkspaceURLOrDefault(&config, "tfWorkspaceMissing"); err == nil {
+		t.Error("Expected an error finding a Workspace that does not exist")
+	}
+}
+
+func TestWorkspaceReference(t *testing.T) {
+	config := GetConfig()
+	apiClient := config.NewOneFuseApiClient()
+
+	workspace, err := apiClient.CreateWorkspace(&Workspace{Name: "tfWorkspaceReference"})
+	if err != nil {
+		t.Fatalf("Error creating Workspace: '%s'", err)
+	}
+	defer apiClient.DeleteWorkspace(workspace.ID)
+
+	// OneFuse does not give links a title, so names are resolved to compare them.
+	link := LinkRef{Href: onefusetest.Href(WorkspaceResourceType, workspace.ID)}
+	workspaceURL := itemURL(&config, WorkspaceResourceType, workspace.ID)
+	for _, configured := range []string{workspace.Name, fmt.Sprint(workspace.ID), workspaceURL, link.Href} {
+		d := schema.TestResourceDataRaw(t, resourceDNSPolicy().Schema, map[string]interface{}{"workspace_url": configured})
+		if actual := workspaceReference(&config, d, link); actual != configured {
+			t.Errorf("Expected workspace_url '%s' to be kept but got '%s'", configured, actual)
+		}
+	}
+
+	for _, configured := range []string{"Default", "1", "tfWorkspaceMissing"} {
+		d := schema.TestResourceDataRaw(t, resourceDNSPolicy().Schema, map[string]interface{}{"workspace_url": configured})
+		if actual := workspaceReference(&config, d, link); actual != link.Href {
+			t.Errorf("Expected workspace_url '%s' to be replaced by '%s' but got '%s'", configured, link.Href, actual)
+		}
+	}
+}
+
+func TestAccResourceWorkspace(t *testing.T) {
+	resourceName := "onefuse_workspace.workspace"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroyed("onefuse_workspace", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetWorkspace(id)
+				return err
+			}),
+			testAccCheckDestroyed("onefuse_credential", func(apiClient *OneFuseAPIClient, id int) error {
+				_, err := apiClient.GetCredential(id)
+				return err
+			}),
+		),
+		Steps: []resource.TestStep{
+			{
+				// Credentials take the workspace by name.
+				Config: testAccWorkspaceConfig("Created by the acceptance tests", "onefuse_workspace.workspace.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, func(apiClient *OneFuseAPIClient, id int) error {
+						_, err := apiClient.GetWorkspace(id)
+						return err
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfAccWorkspace"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Created by the acceptance tests"),
+					resource.TestCheckResourceAttr("onefuse_credential.credential", "workspace_url", "tfAccWorkspace"),
+					resource.TestCheckResourceAttrPair("data.onefuse_workspace.workspace", "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair("data.onefuse_workspace.workspace", "description", resourceName, "description"),
+				),
+			},
+			{
+				// And by ID.
+				Config: testAccWorkspaceConfig("Updated by the acceptance tests", "onefuse_workspace.workspace.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated by the acceptance tests"),
+					resource.TestCheckResourceAttrPair("onefuse_credential.credential", "workspace_url", resourceName, "id"),
+				),
+			},
+			{
+				Config:            testAccWorkspaceConfig("Updated by the acceptance tests", "onefuse_workspace.workspace.id"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Optional attributes removed from the configuration are cleared in OneFuse
+				Config: testAccProviderConfig() + `
+resource "onefuse_workspace" "workspace" {
+  name = "tfAccWorkspace"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccWorkspaceConfig(description string, workspaceReference string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "onefuse_workspace" "workspace" {
+  name        = "tfAccWorkspace"
+  description = %q
+}
+
+data "onefuse_workspace" "workspace" {
+  name = onefuse_workspace.workspace.name
+}
+
+resource "onefuse_credential" "credential" {
+  name          = "tfAccWorkspaceCredential"
+  username      = "svc_onefuse"
+  password      = "Secret01!"
+  workspace_url = %s
+}
+`, description, workspaceReference)
+}
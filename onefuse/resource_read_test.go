@@ -0,0 +1,92 @@
+// Copyright 2020 CloudBolt Software
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package onefuse
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var readTestResources = []struct {
+	name     string
+	resource *schema.Resource
+}{
+	{"onefuse_ansible_tower_deployment", resourceAnsibleTowerDeployment()},
+	{"onefuse_ansible_tower_endpoint", resourceEndpoint(AnsibleTowerEndpointType, "Ansible Tower Endpoint")},
+	{"onefuse_ansible_tower_policy", resourceAnsibleTowerPolicy()},
+	{"onefuse_bluecat_endpoint", resourceEndpoint(BluecatEndpointType, "BlueCat Endpoint")},
+	{"onefuse_credential", resourceCredential()},
+	{"onefuse_dns_policy", resourceDNSPolicy()},
+	{"onefuse_dns_record", resourceDNSReservation()},
+	{"onefuse_infoblox_endpoint", resourceEndpoint(InfobloxEndpointType, "Infoblox Endpoint")},
+	{"onefuse_ipam_policy", resourceIPAMPolicy()},
+	{"onefuse_ipam_record", resourceIPAMReservation()},
+	{"onefuse_menandmice_endpoint", resourceEndpoint(MenAndMiceEndpointType, "Men&Mice Endpoint")},
+	{"onefuse_microsoft_ad_computer_account", resourceMicrosoftADComputerAccount()},
+	{"onefuse_microsoft_ad_policy", resourceMicrosoftADPolicy()},
+	{"onefuse_microsoft_endpoint", resourceMicrosoftEndpoint()},
+	{"onefuse_module_deployment", resourceModuleDeployment()},
+	{"onefuse_module_endpoint", resourceEndpoint(ModuleEndpointType, "Module Endpoint")},
+	{"onefuse_module_policy", resourceModulePolicy()},
+	{"onefuse_naming", resourceCustomNaming()},
+	{"onefuse_naming_policy", resourceNamingPolicy()},
+	{"onefuse_scripting_deployment", resourceScriptingDeployment()},
+	{"onefuse_scripting_policy", resourceScriptingPolicy()},
+	{"onefuse_servicenow_cmdb_deployment", resourceServicenowCMDBDeployment()},
+	{"onefuse_servicenow_cmdb_policy", resourceServicenowCMDBPolicy()},
+	{"onefuse_servicenow_endpoint", resourceEndpoint(ServicenowEndpointType, "ServiceNow Endpoint")},
+	{"onefuse_static_property_set", resourceStaticPropertySet()},
+	{"onefuse_vra_deployment", resourceVraDeployment()},
+	{"onefuse_vra_endpoint", resourceEndpoint(VraEndpointType, "vRA Endpoint")},
+	{"onefuse_vra_policy", resourceVraPolicy()},
+	{"onefuse_workspace", resourceWorkspace()},
+}
+
+func newReadTestResourceData(t *testing.T, resource *schema.Resource) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId("1")
+	if _, ok := resource.Schema["custom_name_id"]; ok {
+		d.Set("custom_name_id", 1)
+	}
+	return d
+}
+
+func TestResourceReadRemovesDeletedObjects(t *testing.T) {
+	server := newErrorTestServer(http.StatusNotFound, `{"detail": "Not found."}`)
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+
+	for _, table := range readTestResources {
+		d := newReadTestResourceData(t, table.resource)
+		if err := table.resource.Read(d, config); err != nil {
+			t.Errorf("Error reading deleted %s: '%s'", table.name, err)
+		}
+		if d.Id() != "" {
+			t.Errorf("Expected deleted %s to be removed from state but ID is '%s'", table.name, d.Id())
+		}
+	}
+}
+
+func TestResourceReadFailsOnOtherErrors(t *testing.T) {
+	server := newErrorTestServer(http.StatusForbidden, `{"detail": "You do not have permission to perform this action."}`)
+	defer server.Close()
+
+	config := configForTestServer(t, server)
+
+	for _, table := range readTestResources {
+		d := newReadTestResourceData(t, table.resource)
+		if err := table.resource.Read(d, config); err == nil {
+			t.Errorf("Expected an error reading %s", table.name)
+		}
+		if d.Id() != "1" {
+			t.Errorf("Expected %s to stay in state but ID is '%s'", table.name, d.Id())
+		}
+	}
+}